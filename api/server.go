@@ -2,8 +2,13 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/avirooppal/gosysutil/cpu"
@@ -12,27 +17,130 @@ import (
 	"github.com/avirooppal/gosysutil/memory"
 	"github.com/avirooppal/gosysutil/network"
 	"github.com/avirooppal/gosysutil/process"
+	"github.com/avirooppal/gosysutil/sampler"
 	"github.com/avirooppal/gosysutil/system"
 )
 
+// The samplers serve usage to the handlers without blocking each
+// request on a sampling window. They are started by RegisterRoutes.
+var (
+	cpuSampler     = cpu.NewSampler(time.Second)
+	diskSampler    = disk.NewSampler(time.Second)
+	networkSampler = network.NewSampler(time.Second)
+)
+
 // HandleCPU returns CPU statistics with detailed usage breakdown
 func HandleCPU(w http.ResponseWriter, r *http.Request) {
-	usage, err := cpu.GetCPUUsage()
+	usage, err := cpuSampler.Usage()
+	if errors.Is(err, sampler.ErrNotReady) {
+		// Usage needs two samples; report that rather than failing the request
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"ready": false})
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	perCPU, err := cpuSampler.PerCPU()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Frequencies are optional: cpufreq and cpu MHz are both hidden in some containers
+	freqs, _ := cpu.GetFrequencies()
+	freqByCore := make(map[int]cpu.CPUFrequency, len(freqs))
+	for _, f := range freqs {
+		freqByCore[f.Core] = f
+	}
+
+	var cores []map[string]interface{}
+	for i := range perCPU {
+		core := formatCPUUsage(&perCPU[i])
+		core["core"] = perCPU[i].Core
+		if f, ok := freqByCore[perCPU[i].Core]; ok {
+			core["frequency"] = fmt.Sprintf("%.0f MHz", f.CurrentMHz)
+			core["frequency_source"] = f.Source
+			// Limits and governor only exist when cpufreq is present
+			if f.Source == "cpufreq" {
+				core["min_frequency"] = fmt.Sprintf("%.0f MHz", f.MinMHz)
+				core["max_frequency"] = fmt.Sprintf("%.0f MHz", f.MaxMHz)
+				core["governor"] = f.Governor
+			}
+		}
+		cores = append(cores, core)
+	}
+
+	response := formatCPUUsage(usage)
+	response["ready"] = true
+	response["per_core"] = cores
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleCPUInfo returns the CPU hardware inventory (model, caches, topology)
+func HandleCPUInfo(w http.ResponseWriter, r *http.Request) {
+	info, err := cpu.GetInfo()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	type ReadableCache struct {
+		Level     int    `json:"level"`
+		Type      string `json:"type"`
+		Size      string `json:"size"`
+		Instances int    `json:"instances"`
+	}
+
+	var caches []ReadableCache
+	for _, c := range info.Caches {
+		caches = append(caches, ReadableCache{
+			Level:     c.Level,
+			Type:      c.Type,
+			Size:      formatBytes(c.SizeBytes),
+			Instances: c.Instances,
+		})
+	}
+
 	response := map[string]interface{}{
-		"total_usage":  fmt.Sprintf("%.2f%%", usage.TotalPercent),
-		"user_usage":   fmt.Sprintf("%.2f%%", usage.UserPercent),
-		"system_usage": fmt.Sprintf("%.2f%%", usage.SystemPercent),
-		"idle_usage":   fmt.Sprintf("%.2f%%", usage.IdlePercent),
+		"model_name":       info.ModelName,
+		"vendor":           info.Vendor,
+		"family":           info.Family,
+		"model":            info.Model,
+		"stepping":         info.Stepping,
+		"microcode":        info.Microcode,
+		"flags":            info.Flags,
+		"caches":           caches,
+		"sockets":          info.Sockets,
+		"physical_cores":   info.PhysicalCores,
+		"threads_per_core": info.ThreadsPerCore,
+		"logical_cpus":     info.LogicalCPUs,
+		"online":           info.Online,
+		"offline":          info.Offline,
 	}
 	respondWithJSON(w, http.StatusOK, response)
 }
 
-// HandleDisk returns Disk statistics with human-readable sizes
+// formatCPUUsage renders the full CPU time breakdown as percentages
+func formatCPUUsage(u *cpu.CPUUsage) map[string]interface{} {
+	return map[string]interface{}{
+		"total_usage":      fmt.Sprintf("%.2f%%", u.TotalPercent),
+		"user_usage":       fmt.Sprintf("%.2f%%", u.UserPercent),
+		"nice_usage":       fmt.Sprintf("%.2f%%", u.NicePercent),
+		"system_usage":     fmt.Sprintf("%.2f%%", u.SystemPercent),
+		"idle_usage":       fmt.Sprintf("%.2f%%", u.IdlePercent),
+		"iowait_usage":     fmt.Sprintf("%.2f%%", u.IowaitPercent),
+		"irq_usage":        fmt.Sprintf("%.2f%%", u.IrqPercent),
+		"softirq_usage":    fmt.Sprintf("%.2f%%", u.SoftirqPercent),
+		"steal_usage":      fmt.Sprintf("%.2f%%", u.StealPercent),
+		"guest_usage":      fmt.Sprintf("%.2f%%", u.GuestPercent),
+		"guest_nice_usage": fmt.Sprintf("%.2f%%", u.GuestNicePercent),
+	}
+}
+
+// HandleDisk returns Disk statistics with I/O rates, utilization and latency
+// over the last second, like iostat -x
 func HandleDisk(w http.ResponseWriter, r *http.Request) {
 	stats, err := disk.GetDisk()
 	if err != nil {
@@ -40,18 +148,244 @@ func HandleDisk(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Rates are omitted and "ready" is false until the sampler has taken two samples
+	usage, err := diskSampler.Usage()
+	ready := err == nil
+	rates := make(map[string]disk.Usage, len(usage))
+	for _, u := range usage {
+		rates[u.Name] = u
+	}
+
+	// Resolve dm-N to the LVM/crypt name it is known by
+	mapperNames := make(map[string]string)
+	if dms, err := disk.GetDMDevices(); err == nil {
+		for _, dm := range dms {
+			mapperNames[dm.Name] = dm.MapperName
+		}
+	}
+
 	type ReadableDisk struct {
-		Name   string `json:"name"`
-		Reads  uint64 `json:"reads"`
-		Writes uint64 `json:"writes"`
+		Name         string `json:"name"`
+		MapperName   string `json:"mapper_name,omitempty"`
+		Type         string `json:"type"`
+		Parent       string `json:"parent,omitempty"`
+		Rotational   bool   `json:"rotational"`
+		Fields       int    `json:"fields"`
+		Reads        uint64 `json:"reads"`
+		Writes       uint64 `json:"writes"`
+		ReadIOPS     string `json:"read_iops,omitempty"`
+		WriteIOPS    string `json:"write_iops,omitempty"`
+		ReadRate     string `json:"read_rate,omitempty"`
+		WriteRate    string `json:"write_rate,omitempty"`
+		ReadAwait    string `json:"read_await,omitempty"`
+		WriteAwait   string `json:"write_await,omitempty"`
+		AvgQueueSize string `json:"avg_queue_size,omitempty"`
+		Utilization  string `json:"utilization,omitempty"`
+		DiscardIOPS  string `json:"discard_iops,omitempty"`
+		DiscardRate  string `json:"discard_rate,omitempty"`
+		FlushIOPS    string `json:"flush_iops,omitempty"`
 	}
 
-	var readable []ReadableDisk
+	readable := make([]ReadableDisk, 0, len(stats))
 	for _, d := range stats {
-		readable = append(readable, ReadableDisk{
-			Name:   d.Name,
-			Reads:  d.ReadsCompleted,
-			Writes: d.WritesCompleted,
+		rd := ReadableDisk{
+			Name:       d.Name,
+			MapperName: mapperNames[d.Name],
+			Type:       string(d.Type),
+			Parent:     d.Parent,
+			Rotational: d.Rotational,
+			Fields:     d.Fields,
+			Reads:      d.ReadsCompleted,
+			Writes:     d.WritesCompleted,
+		}
+		if u, ok := rates[d.Name]; ok {
+			rd.ReadIOPS = fmt.Sprintf("%.2f", u.ReadsPerSec)
+			rd.WriteIOPS = fmt.Sprintf("%.2f", u.WritesPerSec)
+			rd.ReadRate = formatBytes(uint64(u.ReadBytesPerSec)) + "/s"
+			rd.WriteRate = formatBytes(uint64(u.WriteBytesPerSec)) + "/s"
+			rd.ReadAwait = fmt.Sprintf("%.2f ms", u.ReadAwait)
+			rd.WriteAwait = fmt.Sprintf("%.2f ms", u.WriteAwait)
+			rd.AvgQueueSize = fmt.Sprintf("%.2f", u.AvgQueueSize)
+			rd.Utilization = fmt.Sprintf("%.2f%%", u.UtilPercent)
+			if d.HasDiscard() {
+				rd.DiscardIOPS = fmt.Sprintf("%.2f", u.DiscardsPerSec)
+				rd.DiscardRate = formatBytes(uint64(u.DiscardBytesPerSec)) + "/s"
+			}
+			if d.HasFlush() {
+				rd.FlushIOPS = fmt.Sprintf("%.2f", u.FlushesPerSec)
+			}
+		}
+		readable = append(readable, rd)
+	}
+
+	response := map[string]interface{}{
+		"ready": ready,
+		"disks": readable,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleDiskInfo returns model, size and queue settings of every whole disk,
+// or of one device with ?name=sda
+func HandleDiskInfo(w http.ResponseWriter, r *http.Request) {
+	var names []string
+	requested := r.URL.Query().Get("name")
+	if requested != "" {
+		names = append(names, requested)
+	} else {
+		disks, err := disk.GetDisk(disk.WholeDisks)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, d := range disks {
+			names = append(names, d.Name)
+		}
+	}
+
+	response := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		info, err := disk.GetDeviceInfo(name)
+		if err != nil {
+			if requested != "" && errors.Is(err, os.ErrNotExist) {
+				http.Error(w, fmt.Sprintf("block device %q not found", name), http.StatusNotFound)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		response = append(response, map[string]interface{}{
+			"name":                 info.Name,
+			"size":                 formatBytes(info.Size),
+			"model":                info.Model,
+			"vendor":               info.Vendor,
+			"serial":               info.Serial,
+			"removable":            info.Removable,
+			"read_only":            info.ReadOnly,
+			"rotational":           info.Rotational,
+			"logical_block_size":   info.LogicalBlockSize,
+			"physical_block_size":  info.PhysicalBlockSize,
+			"scheduler":            info.Scheduler,
+			"available_schedulers": info.AvailableSchedulers,
+			"nr_requests":          info.NrRequests,
+			"read_ahead":           formatBytes(info.ReadAheadKB * 1024),
+		})
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleRAID returns md array and device-mapper health. "healthy" is false when
+// any array is degraded or inactive.
+func HandleRAID(w http.ResponseWriter, r *http.Request) {
+	arrays, err := disk.GetRAIDArrays()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	dms, err := disk.GetDMDevices()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	healthy := true
+	readableArrays := []map[string]interface{}{}
+	for _, a := range arrays {
+		if a.Degraded || a.State != "active" {
+			healthy = false
+		}
+
+		var members, failed, spares []string
+		for _, m := range a.Members {
+			switch {
+			case m.Failed:
+				failed = append(failed, m.Name)
+			case m.Spare:
+				spares = append(spares, m.Name)
+			default:
+				members = append(members, m.Name)
+			}
+		}
+
+		array := map[string]interface{}{
+			"name":     a.Name,
+			"state":    a.State,
+			"level":    a.Level,
+			"size":     formatBytes(a.Blocks * 1024),
+			"members":  members,
+			"failed":   failed,
+			"spares":   spares,
+			"status":   a.Status,
+			"degraded": a.Degraded,
+		}
+		if a.Sync != nil {
+			sync := map[string]interface{}{
+				"action":  a.Sync.Action,
+				"pending": a.Sync.Pending,
+			}
+			if !a.Sync.Pending {
+				sync["progress"] = fmt.Sprintf("%.1f%%", a.Sync.Percent)
+				sync["eta"] = a.Sync.ETA.Round(time.Second).String()
+				sync["speed"] = formatBytes(a.Sync.SpeedKBps*1024) + "/s"
+			}
+			array["sync"] = sync
+		}
+		readableArrays = append(readableArrays, array)
+	}
+
+	response := map[string]interface{}{
+		"healthy":       healthy,
+		"arrays":        readableArrays,
+		"device_mapper": dms,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleFilesystems returns capacity and inode usage of every mounted filesystem, like df
+func HandleFilesystems(w http.ResponseWriter, r *http.Request) {
+	// Remote filesystems are opt-in: their statfs can stall on an unreachable server
+	var filters []disk.FilesystemFilter
+	if r.URL.Query().Get("remote") == "true" {
+		filters = append(filters, disk.AllFilesystems)
+	}
+
+	stats, err := disk.GetFilesystems(filters...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type ReadableFilesystem struct {
+		Device      string   `json:"device"`
+		Mountpoint  string   `json:"mountpoint"`
+		FSType      string   `json:"fstype"`
+		Options     []string `json:"options"`
+		Remote      bool     `json:"remote"`
+		Total       string   `json:"total"`
+		Used        string   `json:"used"`
+		Available   string   `json:"available"`
+		Usage       string   `json:"usage"`
+		Inodes      uint64   `json:"inodes"`
+		InodesUsed  uint64   `json:"inodes_used"`
+		InodesUsage string   `json:"inodes_usage"`
+	}
+
+	readable := []ReadableFilesystem{}
+	for _, fs := range stats {
+		readable = append(readable, ReadableFilesystem{
+			Device:      fs.Device,
+			Mountpoint:  fs.Mountpoint,
+			FSType:      fs.FSType,
+			Options:     fs.Options,
+			Remote:      fs.Remote,
+			Total:       formatBytes(fs.Total),
+			Used:        formatBytes(fs.Used),
+			Available:   formatBytes(fs.Available),
+			Usage:       fmt.Sprintf("%.2f%%", fs.UsedPercent),
+			Inodes:      fs.Inodes,
+			InodesUsed:  fs.InodesUsed,
+			InodesUsage: fmt.Sprintf("%.2f%%", fs.InodesUsedPercent),
 		})
 	}
 
@@ -66,23 +400,277 @@ func HandleMemory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	percentUsed := 0.0
-	if stats.Total > 0 {
-		percentUsed = float64(stats.Total-stats.Free) / float64(stats.Total) * 100
+	usage := stats.Usage()
+
+	response := map[string]interface{}{
+		"total_memory":            formatBytes(stats.Total),
+		"used_memory":             formatBytes(stats.Used),
+		"free_memory":             formatBytes(stats.Free),
+		"available_memory":        formatBytes(stats.Available),
+		"buff_cache":              formatBytes(stats.Buffers + stats.Cached + stats.SReclaimable),
+		"shared_memory":           formatBytes(stats.Shmem),
+		"percent_used":            fmt.Sprintf("%.2f%%", usage.UsedPercent),
+		"percent_available":       fmt.Sprintf("%.2f%%", usage.AvailablePercent),
+		"percent_used_with_cache": fmt.Sprintf("%.2f%%", usage.CacheInclusiveUsedPercent),
+		"swap_total":              formatBytes(stats.SwapTotal),
+		"swap_used":               formatBytes(stats.SwapUsed),
+		"swap_percent_used":       fmt.Sprintf("%.2f%%", usage.SwapUsedPercent),
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleMemInfo returns every field of /proc/meminfo in bytes (HugePages_* as page counts)
+func HandleMemInfo(w http.ResponseWriter, r *http.Request) {
+	info, err := memory.GetMemInfo()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, info)
+}
+
+// HandleNUMA returns per-node memory, allocation counters, CPUs and the distance matrix
+func HandleNUMA(w http.ResponseWriter, r *http.Request) {
+	nodes, err := memory.GetNUMANodes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type ReadableNode struct {
+		ID          int    `json:"node"`
+		Total       string `json:"total_memory"`
+		Used        string `json:"used_memory"`
+		Free        string `json:"free_memory"`
+		NumaHit     uint64 `json:"numa_hit"`
+		NumaMiss    uint64 `json:"numa_miss"`
+		NumaForeign uint64 `json:"numa_foreign"`
+		LocalNode   uint64 `json:"local_node"`
+		OtherNode   uint64 `json:"other_node"`
+		CPUs        []int  `json:"cpus"`
+	}
+
+	readable := make([]ReadableNode, 0, len(nodes))
+	distances := make([][]int, 0, len(nodes))
+	for _, n := range nodes {
+		readable = append(readable, ReadableNode{
+			ID:          n.ID,
+			Total:       formatBytes(n.MemTotal),
+			Used:        formatBytes(n.MemUsed),
+			Free:        formatBytes(n.MemFree),
+			NumaHit:     n.NumaHit,
+			NumaMiss:    n.NumaMiss,
+			NumaForeign: n.NumaForeign,
+			LocalNode:   n.LocalNode,
+			OtherNode:   n.OtherNode,
+			CPUs:        n.CPUs,
+		})
+		distances = append(distances, n.Distances)
+	}
+
+	response := map[string]interface{}{
+		"nodes":           readable,
+		"distance_matrix": distances,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleHugePages returns huge page pools, THP settings and thp_* counters
+func HandleHugePages(w http.ResponseWriter, r *http.Request) {
+	stats, err := memory.GetHugePages()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type ReadablePool struct {
+		PageSize   string `json:"page_size"`
+		Total      uint64 `json:"total"`
+		Free       uint64 `json:"free"`
+		Reserved   uint64 `json:"reserved"`
+		Surplus    uint64 `json:"surplus"`
+		Overcommit uint64 `json:"overcommit"`
+		PoolSize   string `json:"pool_size"`
+	}
+
+	pools := make([]ReadablePool, 0, len(stats.Pools))
+	for _, p := range stats.Pools {
+		pools = append(pools, ReadablePool{
+			PageSize:   formatBytes(p.SizeBytes),
+			Total:      p.Total,
+			Free:       p.Free,
+			Reserved:   p.Reserved,
+			Surplus:    p.Surplus,
+			Overcommit: p.Overcommit,
+			PoolSize:   formatBytes(p.Total * p.SizeBytes),
+		})
 	}
 
 	response := map[string]interface{}{
-		"total_memory":   formatBytes(stats.Total),
-		"used_memory":    formatBytes(stats.Used),
-		"free_memory":    formatBytes(stats.Free),
-		"percent_used":   fmt.Sprintf("%.2f%%", percentUsed),
-		"swap_total":     formatBytes(stats.SwapTotal),
-		"swap_used":      formatBytes(stats.SwapUsed),
+		"pools": pools,
+		"transparent_hugepage": map[string]interface{}{
+			"enabled":  stats.THPEnabled,
+			"defrag":   stats.THPDefrag,
+			"counters": stats.THPCounters,
+		},
 	}
 	respondWithJSON(w, http.StatusOK, response)
 }
 
-// HandleNetwork returns Network statistics with human-readable sizes
+// HandleSlab returns the largest slab caches; ?top=N sets how many (default 10)
+func HandleSlab(w http.ResponseWriter, r *http.Request) {
+	top := 10
+	if v := r.URL.Query().Get("top"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			http.Error(w, "top must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		top = n
+	}
+
+	stats, err := memory.GetSlabInfo()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type ReadableCache struct {
+		Name       string `json:"name"`
+		Size       string `json:"size"`
+		ActiveSize string `json:"active_size"`
+		ActiveObjs uint64 `json:"active_objects"`
+		NumObjs    uint64 `json:"total_objects"`
+		ObjSize    uint64 `json:"object_size"`
+	}
+
+	readable := []ReadableCache{}
+	for i, c := range stats.Caches {
+		if i >= top {
+			break
+		}
+		readable = append(readable, ReadableCache{
+			Name:       c.Name,
+			Size:       formatBytes(c.TotalBytes),
+			ActiveSize: formatBytes(c.ActiveBytes),
+			ActiveObjs: c.ActiveObjs,
+			NumObjs:    c.NumObjs,
+			ObjSize:    c.ObjSize,
+		})
+	}
+
+	response := map[string]interface{}{
+		"total":         formatBytes(stats.Total),
+		"reclaimable":   formatBytes(stats.SReclaimable),
+		"unreclaimable": formatBytes(stats.SUnreclaim),
+		"detailed":      stats.Detailed,
+		"caches":        readable,
+	}
+	if !stats.Detailed {
+		response["note"] = "/proc/slabinfo is not readable (requires root); only meminfo totals are available"
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleFragmentation returns free blocks per order and the fragmentation index of
+// every zone from /proc/buddyinfo; ?detail=true adds the per-migratetype breakdown
+func HandleFragmentation(w http.ResponseWriter, r *http.Request) {
+	zones, err := memory.GetBuddyInfo()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type ReadableZone struct {
+		Node               int      `json:"node"`
+		Zone               string   `json:"zone"`
+		Free               string   `json:"free_memory"`
+		FreeBlocks         []uint64 `json:"free_blocks_per_order"`
+		FragmentationIndex []string `json:"fragmentation_index_per_order"`
+	}
+
+	readable := make([]ReadableZone, 0, len(zones))
+	pageSize := uint64(os.Getpagesize())
+	for _, z := range zones {
+		index := make([]string, 0, len(z.FragmentationIndex))
+		for _, f := range z.FragmentationIndex {
+			index = append(index, fmt.Sprintf("%.3f", f))
+		}
+		readable = append(readable, ReadableZone{
+			Node:               z.Node,
+			Zone:               z.Zone,
+			Free:               formatBytes(z.FreePages * pageSize),
+			FreeBlocks:         z.FreeBlocks,
+			FragmentationIndex: index,
+		})
+	}
+
+	response := map[string]interface{}{
+		"zones": readable,
+	}
+
+	if r.URL.Query().Get("detail") == "true" {
+		// pagetypeinfo is root-only on recent kernels, so report why it is missing
+		pageTypes, err := memory.GetPageTypeInfo()
+		if err != nil {
+			response["pagetypeinfo_error"] = err.Error()
+		} else {
+			response["pagetypeinfo"] = pageTypes
+		}
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleCompression returns zram, zswap and KSM statistics with their compression ratios
+func HandleCompression(w http.ResponseWriter, r *http.Request) {
+	stats, err := memory.GetCompression()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	zram := make([]map[string]interface{}, 0, len(stats.Zram))
+	for _, z := range stats.Zram {
+		zram = append(zram, map[string]interface{}{
+			"name":              z.Name,
+			"algorithm":         z.Algorithm,
+			"disk_size":         formatBytes(z.DiskSize),
+			"original_size":     formatBytes(z.OrigDataSize),
+			"compressed_size":   formatBytes(z.ComprDataSize),
+			"memory_used":       formatBytes(z.MemUsedTotal),
+			"compression_ratio": fmt.Sprintf("%.2f", z.CompressionRatio),
+		})
+	}
+
+	response := map[string]interface{}{
+		"zram": zram,
+	}
+	if z := stats.Zswap; z != nil {
+		response["zswap"] = map[string]interface{}{
+			"enabled":           z.Enabled,
+			"compressor":        z.Compressor,
+			"max_pool_percent":  z.MaxPoolPercent,
+			"pool_size":         formatBytes(z.PoolSize),
+			"stored_size":       formatBytes(z.StoredSize),
+			"compression_ratio": fmt.Sprintf("%.2f", z.CompressionRatio),
+		}
+	}
+	if k := stats.KSM; k != nil {
+		response["ksm"] = map[string]interface{}{
+			"run":               k.Run,
+			"pages_shared":      k.PagesShared,
+			"pages_sharing":     k.PagesSharing,
+			"full_scans":        k.FullScans,
+			"saved":             formatBytes(k.SavedBytes),
+			"compression_ratio": fmt.Sprintf("%.2f", k.CompressionRatio),
+		}
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleNetwork returns per-interface byte and packet totals, with throughput,
+// packet, error and drop rates over the last second
 func HandleNetwork(w http.ResponseWriter, r *http.Request) {
 	stats, err := network.GetNetwork()
 	if err != nil {
@@ -90,26 +678,194 @@ func HandleNetwork(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Rates are omitted and "ready" is false until the sampler has taken two samples
+	usage, err := networkSampler.Usage()
+	ready := err == nil
+	rates := make(map[string]network.Usage, len(usage))
+	for _, u := range usage {
+		rates[u.Name] = u
+	}
+
 	type ReadableNetwork struct {
-		Name      string `json:"interface"`
-		Rx        string `json:"received"`
-		Tx        string `json:"sent"`
-		RxPackets uint64 `json:"rx_packets"`
-		TxPackets uint64 `json:"tx_packets"`
+		Name        string `json:"interface"`
+		Rx          string `json:"received"`
+		Tx          string `json:"sent"`
+		RxPackets   uint64 `json:"rx_packets"`
+		TxPackets   uint64 `json:"tx_packets"`
+		RxRate      string `json:"rx_rate,omitempty"`
+		TxRate      string `json:"tx_rate,omitempty"`
+		RxPPS       string `json:"rx_packets_per_sec,omitempty"`
+		TxPPS       string `json:"tx_packets_per_sec,omitempty"`
+		RxErrors    string `json:"rx_errors_per_sec,omitempty"`
+		TxErrors    string `json:"tx_errors_per_sec,omitempty"`
+		RxDropped   string `json:"rx_dropped_per_sec,omitempty"`
+		TxDropped   string `json:"tx_dropped_per_sec,omitempty"`
+		RxMulticast string `json:"rx_multicast_per_sec,omitempty"`
 	}
 
-	var readable []ReadableNetwork
+	readable := make([]ReadableNetwork, 0, len(stats))
 	for _, s := range stats {
-		readable = append(readable, ReadableNetwork{
+		rn := ReadableNetwork{
 			Name:      s.Name,
 			Rx:        formatBytes(s.RxBytes),
 			Tx:        formatBytes(s.TxBytes),
 			RxPackets: s.RxPackets,
 			TxPackets: s.TxPackets,
-		})
+		}
+		if u, ok := rates[s.Name]; ok {
+			rn.RxRate = formatBytes(uint64(u.RxBytesPerSec)) + "/s"
+			rn.TxRate = formatBytes(uint64(u.TxBytesPerSec)) + "/s"
+			rn.RxPPS = fmt.Sprintf("%.2f", u.RxPacketsPerSec)
+			rn.TxPPS = fmt.Sprintf("%.2f", u.TxPacketsPerSec)
+			rn.RxErrors = fmt.Sprintf("%.2f", u.RxErrorsPerSec)
+			rn.TxErrors = fmt.Sprintf("%.2f", u.TxErrorsPerSec)
+			rn.RxDropped = fmt.Sprintf("%.2f", u.RxDroppedPerSec)
+			rn.TxDropped = fmt.Sprintf("%.2f", u.TxDroppedPerSec)
+			rn.RxMulticast = fmt.Sprintf("%.2f", u.RxMulticastPerSec)
+		}
+		readable = append(readable, rn)
 	}
 
-	respondWithJSON(w, http.StatusOK, readable)
+	response := map[string]interface{}{
+		"ready":      ready,
+		"interfaces": readable,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleNetworkInterfaces returns link state, speed, MTU, driver and
+// bridge/bond relationships of every interface, with link utilization
+// over the last second where the speed is known
+func HandleNetworkInterfaces(w http.ResponseWriter, r *http.Request) {
+	interfaces, err := network.GetInterfaces()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rates := make(map[string]network.Usage)
+	if usage, err := networkSampler.Usage(); err == nil {
+		for _, u := range usage {
+			rates[u.Name] = u
+		}
+	}
+
+	response := make([]map[string]interface{}, 0, len(interfaces))
+	for _, iface := range interfaces {
+		entry := map[string]interface{}{
+			"name":         iface.Name,
+			"index":        iface.Index,
+			"type":         iface.Type,
+			"operstate":    iface.OperState,
+			"carrier":      iface.Carrier,
+			"mtu":          iface.MTU,
+			"duplex":       iface.Duplex,
+			"mac":          iface.MAC,
+			"driver":       iface.Driver,
+			"tx_queue_len": iface.TxQueueLen,
+			"master":       iface.Master,
+			"slaves":       iface.Slaves,
+		}
+		if iface.SpeedMbps > 0 {
+			entry["speed"] = fmt.Sprintf("%d Mb/s", iface.SpeedMbps)
+			if u, ok := rates[iface.Name]; ok {
+				entry["utilization"] = fmt.Sprintf("%.2f%%", iface.Utilization(&u))
+			}
+		}
+		response = append(response, entry)
+	}
+
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleNetworkAddresses returns the IPv4 and IPv6 addresses of every interface
+func HandleNetworkAddresses(w http.ResponseWriter, r *http.Request) {
+	addresses, err := network.GetAddresses()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, addresses)
+}
+
+// HandleNetworkRoutes returns the IPv4 and IPv6 routing tables and the default gateway
+func HandleNetworkRoutes(w http.ResponseWriter, r *http.Request) {
+	routes, err := network.GetRoutes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"default_gateway": network.DefaultGateway(routes),
+		"routes":          routes,
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleConnections returns the socket table with owning processes, like ss -tanp.
+// Query parameters: proto (tcp, tcp4, tcp6, udp, udp4, udp6, inet, unix, all;
+// default tcp), state (e.g. ESTABLISHED) and port (local or remote).
+func HandleConnections(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	proto := query.Get("proto")
+	switch proto {
+	case "":
+		proto = "tcp"
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6", "inet", "unix", "all":
+	default:
+		http.Error(w, fmt.Sprintf("invalid proto %q", proto), http.StatusBadRequest)
+		return
+	}
+	state := strings.ToUpper(query.Get("state"))
+	port := -1
+	if p := query.Get("port"); p != "" {
+		var err error
+		if port, err = strconv.Atoi(p); err != nil || port < 0 || port > 65535 {
+			http.Error(w, fmt.Sprintf("invalid port %q", p), http.StatusBadRequest)
+			return
+		}
+	}
+
+	connections, err := network.GetConnections(proto)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	filtered := []network.Connection{}
+	for _, c := range connections {
+		if state != "" && c.State != state {
+			continue
+		}
+		if port >= 0 && c.LocalPort != port && c.RemotePort != port {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+
+	respondWithJSON(w, http.StatusOK, filtered)
+}
+
+// HandleListeners returns every listening TCP and bound UDP socket with its
+// owning process and accept queue, next to the system-wide listen overflow counters
+func HandleListeners(w http.ResponseWriter, r *http.Request) {
+	listeners, err := network.GetListeners()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"listeners": listeners,
+	}
+	// Overflows count SYNs dropped because an accept queue was full
+	if netstat, err := system.GetNetStatStats(); err == nil {
+		response["listen_overflows"] = netstat.TCPListenOverflows
+		response["listen_drops"] = netstat.TCPListenDrops
+	}
+	respondWithJSON(w, http.StatusOK, response)
 }
 
 // HandleProcess returns Process statistics with human-readable RSS
@@ -142,13 +898,15 @@ func HandleProcess(w http.ResponseWriter, r *http.Request) {
 
 // HandleAll returns a comprehensive summary of all system statistics
 func HandleAll(w http.ResponseWriter, r *http.Request) {
-	cpuUsg, _ := cpu.GetCPUUsage()
+	cpuUsg, err := cpuSampler.Usage()
+	if err != nil {
+		cpuUsg = &cpu.CPUUsage{}
+	}
 	memStats, _ := memory.GetMemory()
 	diskStats, _ := disk.GetDisk()
 	netStats, _ := network.GetNetwork()
 	loadAvg, _ := system.GetLoadAvg()
 	uptimeStats, _ := system.GetUptime()
-	stealStats, _ := system.GetStealIOWait()
 	topCPU, _ := process.GetTopByCPU(5)
 	topRAM, _ := process.GetTopByMemory(5)
 	sockStats, _ := system.GetSockStats()
@@ -158,10 +916,7 @@ func HandleAll(w http.ResponseWriter, r *http.Request) {
 	ioPressure, _ := system.GetIOPressure()
 	gpuStats, _ := gpu.GetGPUInfo()
 	
-	percentUsed := 0.0
-	if memStats.Total > 0 {
-		percentUsed = float64(memStats.Total-memStats.Free) / float64(memStats.Total) * 100
-	}
+	memUsage := memStats.Usage()
 
 	// Refine Disks
 	type ReadableDisk struct {
@@ -211,7 +966,8 @@ func HandleAll(w http.ResponseWriter, r *http.Request) {
 			"total":      formatBytes(memStats.Total),
 			"used":       formatBytes(memStats.Used),
 			"free":       formatBytes(memStats.Free),
-			"usage":      fmt.Sprintf("%.2f%%", percentUsed),
+			"usage":      fmt.Sprintf("%.2f%%", memUsage.UsedPercent),
+			"swap_usage": fmt.Sprintf("%.2f%%", memUsage.SwapUsedPercent),
 			"swap_total": formatBytes(memStats.SwapTotal),
 			"swap_used":  formatBytes(memStats.SwapUsed),
 		},
@@ -225,8 +981,8 @@ func HandleAll(w http.ResponseWriter, r *http.Request) {
 			"formatted": formatDuration(uptimeStats.Uptime),
 		},
 		"steal_iowait": map[string]interface{}{
-			"steal_percent":  fmt.Sprintf("%.2f%%", stealStats.StealPercent),
-			"iowait_percent": fmt.Sprintf("%.2f%%", stealStats.IOWaitPercent),
+			"steal_percent":  fmt.Sprintf("%.2f%%", cpuUsg.StealPercent),
+			"iowait_percent": fmt.Sprintf("%.2f%%", cpuUsg.IowaitPercent),
 		},
 		"sockets": map[string]interface{}{
 			"used":      sockStats.SocketsUsed,
@@ -346,15 +1102,20 @@ func HandleTopRAM(w http.ResponseWriter, r *http.Request) {
 
 // HandleSteal returns CPU steal and IO wait percentages (VPS specific)
 func HandleSteal(w http.ResponseWriter, r *http.Request) {
-	stats, err := system.GetStealIOWait()
+	usage, err := cpuSampler.Usage()
+	if errors.Is(err, sampler.ErrNotReady) {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"ready": false})
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	response := map[string]interface{}{
-		"steal_percent":  fmt.Sprintf("%.2f%%", stats.StealPercent),
-		"iowait_percent": fmt.Sprintf("%.2f%%", stats.IOWaitPercent),
+		"ready":          true,
+		"steal_percent":  fmt.Sprintf("%.2f%%", usage.StealPercent),
+		"iowait_percent": fmt.Sprintf("%.2f%%", usage.IowaitPercent),
 		"description":    "Steal time indicates CPU cycles taken by hypervisor. IOWait indicates CPU waiting for disk I/O.",
 	}
 	respondWithJSON(w, http.StatusOK, response)
@@ -564,6 +1325,45 @@ func HandleNetStat(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, response)
 }
 
+// HandleKernelActivity returns context switches, forks, running/blocked tasks and boot time from /proc/stat
+func HandleKernelActivity(w http.ResponseWriter, r *http.Request) {
+	stats, err := system.GetKernelActivity()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"context_switches": stats.ContextSwitches,
+		"interrupts":       stats.Interrupts,
+		"forks":            stats.Forks,
+		"procs_running":    stats.ProcsRunning,
+		"procs_blocked":    stats.ProcsBlocked,
+		"boot_time":        stats.BootTime.Format(time.RFC3339),
+	}
+	respondWithJSON(w, http.StatusOK, response)
+}
+
+// HandleInterrupts returns per-IRQ, per-CPU interrupt counts from /proc/interrupts
+func HandleInterrupts(w http.ResponseWriter, r *http.Request) {
+	stats, err := system.GetInterrupts()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, stats)
+}
+
+// HandleSoftIRQs returns per-CPU softirq counts from /proc/softirqs
+func HandleSoftIRQs(w http.ResponseWriter, r *http.Request) {
+	stats, err := system.GetSoftIRQs()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, stats)
+}
+
 // HandleGPU returns GPU statistics from nvidia-smi
 func HandleGPU(w http.ResponseWriter, r *http.Request) {
 	stats, err := gpu.GetGPUInfo()
@@ -578,11 +1378,37 @@ func HandleGPU(w http.ResponseWriter, r *http.Request) {
 }
 
 // RegisterRoutes registers the API routes to the given multiplexer
+// and starts the background CPU, disk and network samplers they read from
 func RegisterRoutes(mux *http.ServeMux) {
+	if err := cpuSampler.Start(); err != nil {
+		log.Printf("cpu sampler: %v", err)
+	}
+	if err := diskSampler.Start(); err != nil {
+		log.Printf("disk sampler: %v", err)
+	}
+	if err := networkSampler.Start(); err != nil {
+		log.Printf("network sampler: %v", err)
+	}
+
 	mux.HandleFunc("/api/cpu", HandleCPU)
+	mux.HandleFunc("/api/cpu/info", HandleCPUInfo)
 	mux.HandleFunc("/api/disk", HandleDisk)
+	mux.HandleFunc("/api/disk/info", HandleDiskInfo)
+	mux.HandleFunc("/api/raid", HandleRAID)
+	mux.HandleFunc("/api/filesystems", HandleFilesystems)
 	mux.HandleFunc("/api/memory", HandleMemory)
+	mux.HandleFunc("/api/meminfo", HandleMemInfo)
+	mux.HandleFunc("/api/numa", HandleNUMA)
+	mux.HandleFunc("/api/hugepages", HandleHugePages)
+	mux.HandleFunc("/api/slab", HandleSlab)
+	mux.HandleFunc("/api/fragmentation", HandleFragmentation)
+	mux.HandleFunc("/api/compression", HandleCompression)
 	mux.HandleFunc("/api/network", HandleNetwork)
+	mux.HandleFunc("/api/network/interfaces", HandleNetworkInterfaces)
+	mux.HandleFunc("/api/network/addresses", HandleNetworkAddresses)
+	mux.HandleFunc("/api/network/routes", HandleNetworkRoutes)
+	mux.HandleFunc("/api/connections", HandleConnections)
+	mux.HandleFunc("/api/listeners", HandleListeners)
 	mux.HandleFunc("/api/process", HandleProcess)
 	mux.HandleFunc("/api/all", HandleAll)
 
@@ -592,6 +1418,7 @@ func RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/topcpu", HandleTopCPU)
 	mux.HandleFunc("/api/topram", HandleTopRAM)
 	mux.HandleFunc("/api/steal", HandleSteal)
+	mux.HandleFunc("/api/kernel", HandleKernelActivity)
 
 	// Advanced metrics
 	mux.HandleFunc("/api/sockstat", HandleSockStats)
@@ -601,6 +1428,8 @@ func RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/snmp", HandleSNMP)
 
 	mux.HandleFunc("/api/netstat", HandleNetStat)
+	mux.HandleFunc("/api/interrupts", HandleInterrupts)
+	mux.HandleFunc("/api/softirqs", HandleSoftIRQs)
 
 	// GPU metrics
 	mux.HandleFunc("/api/gpu", HandleGPU)
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/avirooppal/gosysutil/cpu"
 	"github.com/avirooppal/gosysutil/monitor"
 )
 
@@ -12,6 +13,7 @@ type tickMsg time.Time
 type model struct {
 	lastStats    *monitor.SystemStats
 	currentStats *monitor.SystemStats
+	cpuSampler   *cpu.Sampler
 	err          error
 	width        int
 	height       int
@@ -19,8 +21,15 @@ type model struct {
 
 func initialModel() model {
 	stats, err := monitor.GetSystemStats()
+
+	sampler := cpu.NewSampler(time.Second)
+	if startErr := sampler.Start(); err == nil {
+		err = startErr
+	}
+
 	return model{
 		currentStats: stats,
+		cpuSampler:   sampler,
 		err:          err,
 	}
 }
@@ -34,6 +43,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "q", "ctrl+c", "esc":
+			m.cpuSampler.Stop()
 			return m, tea.Quit
 		}
 	
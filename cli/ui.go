@@ -2,11 +2,12 @@ package main
 
 import (
 	"fmt"
-	"math"
     "sort"
 	"strings"
 
 	// tea "github.com/charmbracelet/bubbletea"
+	"github.com/avirooppal/gosysutil/disk"
+	"github.com/avirooppal/gosysutil/network"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -46,32 +47,49 @@ func (m model) View() string {
 	header := titleStyle.Render(" SYSTEM MONITOR ")
 
 	cpuUsage := 0.0
-	if m.lastStats != nil && m.currentStats != nil {
-		deltaTotal := float64(m.currentStats.CPU.Total - m.lastStats.CPU.Total)
-		deltaIdle := float64(m.currentStats.CPU.Idle - m.lastStats.CPU.Idle)
-        if deltaTotal > 0 {
-		    cpuUsage = ((deltaTotal - deltaIdle) / deltaTotal) * 100
-        }
-	} else if m.currentStats != nil {
-        // Fallback for first frame
-        cpuUsage = 0.0 
-    }
+	if usage, err := m.cpuSampler.Usage(); err == nil {
+		cpuUsage = usage.TotalPercent
+	}
+
+	// Per-core usage, laid out in columns of 8 cores
+	perCPU, _ := m.cpuSampler.PerCPU()
+	freqByCore := make(map[int]float64)
+	for _, f := range m.currentStats.CPUFreq {
+		freqByCore[f.Core] = f.CurrentMHz
+	}
+	var coreColumns, coreRows []string
+	for i, c := range perCPU {
+		freq := "     "
+		if mhz, ok := freqByCore[c.Core]; ok {
+			freq = fmt.Sprintf("%.1fG", mhz/1000)
+		}
+		coreRows = append(coreRows, fmt.Sprintf("%-5s %s %5.1f%% %s",
+			fmt.Sprintf("cpu%d", c.Core),
+			renderProgressBar(c.TotalPercent, 10),
+			c.TotalPercent,
+			freq,
+		))
+		if len(coreRows) == 8 || i == len(perCPU)-1 {
+			coreColumns = append(coreColumns, strings.Join(coreRows, "\n")+"  ")
+			coreRows = nil
+		}
+	}
 
 	// CPU View
 	cpuBar := renderProgressBar(cpuUsage, 30) // Fixed width for now
 	cpuSection := sectionStyle.Render(fmt.Sprintf(
-		"%s\n\nUsage: %s %.1f%%",
+		"%s\n\nUsage: %s %.1f%%\n\n%s",
 		labelStyle.Render("CPU"),
 		cpuBar,
 		cpuUsage,
+		lipgloss.JoinHorizontal(lipgloss.Top, coreColumns...),
 	))
 
 	// Memory View
 	memUsedGB := float64(m.currentStats.Memory.Used) / 1024 / 1024 / 1024
 	memTotalGB := float64(m.currentStats.Memory.Total) / 1024 / 1024 / 1024
-    memUsagePct := (memUsedGB / memTotalGB) * 100
-    if math.IsNaN(memUsagePct) { memUsagePct = 0 }
-    
+	memUsagePct := m.currentStats.Memory.Usage().UsedPercent
+
     memBar := renderProgressBar(memUsagePct, 30)
 	memSection := sectionStyle.Render(fmt.Sprintf(
 		"%s\n\nUsage: %s %.1f%%\nFrom:  %.2f GB / %.2f GB",
@@ -82,11 +100,23 @@ func (m model) View() string {
 		memTotalGB,
 	))
 
-    // Disks View (First 3)
+    // Disks View (First 3), throughput against the previous tick
+    diskRates := make(map[string]disk.Usage)
+    if m.lastStats != nil {
+        for _, u := range disk.CalculateUsage(m.lastStats.Disks, m.currentStats.Disks) {
+            diskRates[u.Name] = u
+        }
+    }
     var diskRows []string
     for i, d := range m.currentStats.Disks {
         if i >= 3 { break }
-        diskRows = append(diskRows, fmt.Sprintf("%-10s R:%d W:%d", d.Name, d.ReadsCompleted, d.WritesCompleted))
+        u := diskRates[d.Name]
+        diskRows = append(diskRows, fmt.Sprintf("%-8s R %s/s W %s/s %3.0f%%",
+            d.Name,
+            humanizeBytes(u.ReadBytesPerSec),
+            humanizeBytes(u.WriteBytesPerSec),
+            u.UtilPercent,
+        ))
     }
     if len(diskRows) == 0 { diskRows = append(diskRows, "No disks found") }
     diskSection := sectionStyle.Render(fmt.Sprintf(
@@ -95,28 +125,48 @@ func (m model) View() string {
         strings.Join(diskRows, "\n"),
     ))
     
-    // Network View (First 3)
+    // Filesystems View (Fullest 4)
+    filesystems := make([]disk.Filesystem, len(m.currentStats.Filesystems))
+    copy(filesystems, m.currentStats.Filesystems)
+    sort.Slice(filesystems, func(i, j int) bool {
+        return filesystems[i].UsedPercent > filesystems[j].UsedPercent
+    })
+    var fsRows []string
+    for i, fs := range filesystems {
+        if i >= 4 { break }
+        mount := fs.Mountpoint
+        if len(mount) > 16 { mount = "..." + mount[len(mount)-13:] }
+        fsRows = append(fsRows, fmt.Sprintf("%-16s %s %5.1f%% %9s / %-9s inodes %4.1f%%",
+            mount,
+            renderProgressBar(fs.UsedPercent, 20),
+            fs.UsedPercent,
+            humanizeBytes(float64(fs.Used)),
+            humanizeBytes(float64(fs.Total)),
+            fs.InodesUsedPercent,
+        ))
+    }
+    if len(fsRows) == 0 { fsRows = append(fsRows, "No filesystems found") }
+    fsSection := sectionStyle.Render(fmt.Sprintf(
+        "%s\n\n%s",
+        labelStyle.Render("FILESYSTEMS"),
+        strings.Join(fsRows, "\n"),
+    ))
+
+    // Network View (First 3), throughput against the previous tick
+    netRates := make(map[string]network.Usage)
+    if m.lastStats != nil {
+        for _, u := range network.CalculateUsage(m.lastStats.Network, m.currentStats.Network) {
+            netRates[u.Name] = u
+        }
+    }
     var netRows []string
     for i, n := range m.currentStats.Network {
         if i >= 3 { break }
-        // Calc speed if possible
-        rxSpeed := 0.0
-        txSpeed := 0.0
-        if m.lastStats != nil {
-             // Find matching interface in lastStats
-             for _, lastN := range m.lastStats.Network {
-                 if lastN.Name == n.Name {
-                     rxSpeed = float64(n.RxBytes - lastN.RxBytes) // Bytes per second (since ticker is 1s)
-                     txSpeed = float64(n.TxBytes - lastN.TxBytes)
-                     break
-                 }
-             }
-        }
-        
+        u := netRates[n.Name]
         netRows = append(netRows, fmt.Sprintf("%-6s ↓ %s/s ↑ %s/s", 
             n.Name, 
-            humanizeBytes(rxSpeed), 
-            humanizeBytes(txSpeed),
+            humanizeBytes(u.RxBytesPerSec), 
+            humanizeBytes(u.TxBytesPerSec),
         ))
     }
     if len(netRows) == 0 { netRows = append(netRows, "No interfaces found") }
@@ -200,16 +250,17 @@ func (m model) View() string {
         strings.Join(procRows, "\n"),
     ))
 
-	// Layout: Top Row (CPU + Mem), Bottom Row (Disk + Net)
+	// Layout: Top Row (CPU + Mem), Bottom Row (Disk + Net), then Filesystems and Processes
     // We join horizontally using lipgloss.JoinHorizontal
     topRow := lipgloss.JoinHorizontal(lipgloss.Top, cpuSection, memSection)
     bottomRow := lipgloss.JoinHorizontal(lipgloss.Top, diskSection, netSection)
 
 	return appStyle.Render(fmt.Sprintf(
-		"%s\n\n%s\n\n%s\n\n%s",
+		"%s\n\n%s\n\n%s\n\n%s\n\n%s\n\n%s",
 		header,
 		topRow,
 		bottomRow,
+        fsSection,
         procSection,
 		labelStyle.Render("Press 'q' or 'esc' to quit"),
 	))
@@ -1,3 +1,4 @@
+//go:build linux
 // +build linux
 
 package cpu
@@ -5,6 +6,7 @@ package cpu
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -24,30 +26,82 @@ type CPUStats struct {
 	Guest     uint64
 	GuestNice uint64
 	Total     uint64
+	// Core is the index N of a cpuN line, or -1 for the aggregate cpu line
+	Core int
 }
 
 // GetCPU returns the aggregate CPU statistics from /proc/stat
 func GetCPU() (*CPUStats, error) {
-	file, err := os.Open("/proc/stat")
+	total, _, err := readStat()
+	if err != nil {
+		return nil, err
+	}
+	return total, nil
+}
+
+// GetPerCPU returns the statistics of every online core from the cpuN lines of /proc/stat
+func GetPerCPU() ([]CPUStats, error) {
+	_, perCPU, err := readStat()
 	if err != nil {
 		return nil, err
 	}
+	return perCPU, nil
+}
+
+// readStat reads the aggregate and per-core cpu lines from a single pass over /proc/stat
+func readStat() (*CPUStats, []CPUStats, error) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return nil, nil, err
+	}
 	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		fields := strings.Fields(line)
-		if len(fields) > 0 && fields[0] == "cpu" {
-			return parseCPULine(fields)
+	return parseStat(file)
+}
+
+// parseStat parses the aggregate and per-core cpu lines of /proc/stat content
+func parseStat(r io.Reader) (*CPUStats, []CPUStats, error) {
+	var total *CPUStats
+	var perCPU []CPUStats
+
+	// The cpu lines come first. Checking the prefix before reading each line
+	// stops the parse before the intr line, which grows with the IRQ count
+	// and can be far longer than a bufio.Scanner token on large hosts.
+	reader := bufio.NewReader(r)
+	for {
+		prefix, err := reader.Peek(3)
+		if err != nil && err != io.EOF {
+			return nil, nil, err
+		}
+		if string(prefix) != "cpu" {
+			break
+		}
+
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, nil, err
+		}
+
+		stats, perr := parseCPULine(strings.Fields(line))
+		if perr != nil {
+			return nil, nil, perr
+		}
+		if stats.Core < 0 {
+			total = stats
+		} else {
+			perCPU = append(perCPU, *stats)
+		}
+
+		if err == io.EOF {
+			break
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	if total == nil {
+		return nil, nil, fmt.Errorf("cpu line not found in /proc/stat")
 	}
 
-	return nil, fmt.Errorf("cpu line not found in /proc/stat")
+	return total, perCPU, nil
 }
 
 func parseCPULine(fields []string) (*CPUStats, error) {
@@ -55,9 +109,16 @@ func parseCPULine(fields []string) (*CPUStats, error) {
 		return nil, fmt.Errorf("insufficient fields in cpu line")
 	}
 
-	stats := &CPUStats{}
+	stats := &CPUStats{Core: -1}
 	var err error
 
+	if fields[0] != "cpu" {
+		stats.Core, err = strconv.Atoi(strings.TrimPrefix(fields[0], "cpu"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpu label %q", fields[0])
+		}
+	}
+
 	stats.User, err = strconv.ParseUint(fields[1], 10, 64)
 	if err != nil {
 		return nil, err
@@ -86,33 +147,43 @@ func parseCPULine(fields []string) (*CPUStats, error) {
 	if err != nil {
 		return nil, err
 	}
-    // Newer kernels have steal, guest, guest_nice
-    if len(fields) > 8 {
-        stats.Steal, _ = strconv.ParseUint(fields[8], 10, 64)
-    }
-    if len(fields) > 9 {
-        stats.Guest, _ = strconv.ParseUint(fields[9], 10, 64)
-    }
-    if len(fields) > 10 {
-        stats.GuestNice, _ = strconv.ParseUint(fields[10], 10, 64)
-    }
+	// Newer kernels have steal, guest, guest_nice
+	if len(fields) > 8 {
+		stats.Steal, _ = strconv.ParseUint(fields[8], 10, 64)
+	}
+	if len(fields) > 9 {
+		stats.Guest, _ = strconv.ParseUint(fields[9], 10, 64)
+	}
+	if len(fields) > 10 {
+		stats.GuestNice, _ = strconv.ParseUint(fields[10], 10, 64)
+	}
 
+	// The kernel already accounts guest time in user and guest_nice in nice,
+	// so adding them again would count virtualised time twice
 	stats.Total = stats.User + stats.Nice + stats.System + stats.Idle +
-		stats.Iowait + stats.Irq + stats.Softirq + stats.Steal +
-		stats.Guest + stats.GuestNice
+		stats.Iowait + stats.Irq + stats.Softirq + stats.Steal
 
 	return stats, nil
 }
 
 // CPUUsage represents more detailed CPU usage percentages
 type CPUUsage struct {
-	TotalPercent  float64 `json:"total_percent"`
-	UserPercent   float64 `json:"user_percent"`
-	SystemPercent float64 `json:"system_percent"`
-	IdlePercent   float64 `json:"idle_percent"`
+	Core             int     `json:"core"`
+	TotalPercent     float64 `json:"total_percent"`
+	UserPercent      float64 `json:"user_percent"`
+	SystemPercent    float64 `json:"system_percent"`
+	IdlePercent      float64 `json:"idle_percent"`
+	NicePercent      float64 `json:"nice_percent"`
+	IowaitPercent    float64 `json:"iowait_percent"`
+	IrqPercent       float64 `json:"irq_percent"`
+	SoftirqPercent   float64 `json:"softirq_percent"`
+	StealPercent     float64 `json:"steal_percent"`
+	GuestPercent     float64 `json:"guest_percent"`
+	GuestNicePercent float64 `json:"guest_nice_percent"`
 }
 
-// GetCPUUsage calculates the CPU usage statistics over a 500ms interval
+// GetCPUUsage calculates the CPU usage statistics over a 500ms interval.
+// Long-running callers should prefer a Sampler, which does not block.
 func GetCPUUsage() (*CPUUsage, error) {
 	s1, err := GetCPU()
 	if err != nil {
@@ -126,19 +197,23 @@ func GetCPUUsage() (*CPUUsage, error) {
 		return nil, err
 	}
 
-	totalDelta := s2.Total - s1.Total
-	if totalDelta == 0 {
-		return &CPUUsage{}, nil
+	return calculateUsage(s1, s2), nil
+}
+
+// GetPerCPUUsage calculates the usage of every core over a 500ms interval.
+// Cores that go offline during the interval are omitted.
+func GetPerCPUUsage() ([]CPUUsage, error) {
+	s1, err := GetPerCPU()
+	if err != nil {
+		return nil, err
 	}
 
-	userDelta := s2.User - s1.User
-	systemDelta := s2.System - s1.System
-	idleDelta := s2.Idle - s1.Idle
+	time.Sleep(500 * time.Millisecond)
+
+	s2, err := GetPerCPU()
+	if err != nil {
+		return nil, err
+	}
 
-	return &CPUUsage{
-		TotalPercent:  float64(totalDelta-idleDelta) / float64(totalDelta) * 100,
-		UserPercent:   float64(userDelta) / float64(totalDelta) * 100,
-		SystemPercent: float64(systemDelta) / float64(totalDelta) * 100,
-		IdlePercent:   float64(idleDelta) / float64(totalDelta) * 100,
-	}, nil
+	return calculatePerCPUUsage(s1, s2), nil
 }
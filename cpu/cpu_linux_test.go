@@ -0,0 +1,117 @@
+//go:build linux
+// +build linux
+
+package cpu
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseStat(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantTotal uint64
+		wantCores []int
+		wantErr   bool
+	}{
+		{
+			name: "all cores online",
+			input: `cpu  400 10 200 4000 40 0 8 0 0 0
+cpu0 100 5 50 1000 10 0 2 0 0 0
+cpu1 100 5 50 1000 10 0 2 0 0 0
+cpu2 100 0 50 1000 10 0 2 0 0 0
+cpu3 100 0 50 1000 10 0 2 0 0 0
+intr 628578 0 0 0
+ctxt 1452674
+btime 1792177342
+`,
+			wantTotal: 4658,
+			wantCores: []int{0, 1, 2, 3},
+		},
+		{
+			// Offline cores have no cpuN line, so the indexes have gaps
+			name: "offline cores",
+			input: `cpu  300 0 150 3000 30 0 6 30 0 0
+cpu0 100 0 50 1000 10 0 2 10 0 0
+cpu1 100 0 50 1000 10 0 2 10 0 0
+cpu5 100 0 50 1000 10 0 2 10 0 0
+intr 628578 0 0 0
+`,
+			wantTotal: 3516,
+			wantCores: []int{0, 1, 5},
+		},
+		{
+			// Guest time is already part of user time and must not be added again
+			name: "guest time",
+			input: `cpu  500 20 100 1000 0 0 0 0 300 20
+cpu0 500 20 100 1000 0 0 0 0 300 20
+`,
+			wantTotal: 1620,
+			wantCores: []int{0},
+		},
+		{
+			name: "kernel without steal and guest",
+			input: `cpu  100 0 50 1000 10 0 2
+cpu0 100 0 50 1000 10 0 2
+`,
+			wantTotal: 1162,
+			wantCores: []int{0},
+		},
+		{
+			// Hosts with thousands of IRQs have an intr line longer than 64KB
+			name: "long intr line",
+			input: "cpu  100 0 50 1000 10 0 2 0 0 0\n" +
+				"cpu0 100 0 50 1000 10 0 2 0 0 0\n" +
+				"intr 628578" + strings.Repeat(" 0", 100000) + "\n" +
+				"ctxt 1452674\n",
+			wantTotal: 1162,
+			wantCores: []int{0},
+		},
+		{
+			name:    "missing aggregate line",
+			input:   "intr 628578 0 0 0\nctxt 1452674\n",
+			wantErr: true,
+		},
+		{
+			name:    "truncated cpu line",
+			input:   "cpu  100 0 50\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid core label",
+			input:   "cpu  100 0 50 1000 10 0 2\ncpuX 100 0 50 1000 10 0 2\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			total, perCPU, err := parseStat(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if total.Core != -1 {
+				t.Errorf("aggregate Core = %d, want -1", total.Core)
+			}
+			if total.Total != tt.wantTotal {
+				t.Errorf("Total = %d, want %d", total.Total, tt.wantTotal)
+			}
+			if len(perCPU) != len(tt.wantCores) {
+				t.Fatalf("got %d cores, want %d", len(perCPU), len(tt.wantCores))
+			}
+			for i, core := range tt.wantCores {
+				if perCPU[i].Core != core {
+					t.Errorf("perCPU[%d].Core = %d, want %d", i, perCPU[i].Core, core)
+				}
+			}
+		})
+	}
+}
@@ -1,3 +1,4 @@
+//go:build windows
 // +build windows
 
 package cpu
@@ -15,6 +16,8 @@ type CPUStats struct {
 	Guest     uint64
 	GuestNice uint64
 	Total     uint64
+	// Core is the index of the core, or -1 for the aggregate statistics
+	Core int
 }
 
 // GetCPU returns mock CPU statistics for Windows
@@ -25,25 +28,57 @@ func GetCPU() (*CPUStats, error) {
 		System: 500,
 		Idle:   8500,
 		Total:  10000,
+		Core:   -1,
 	}, nil
 }
 
+// GetPerCPU returns mock per-core CPU statistics for Windows
+func GetPerCPU() ([]CPUStats, error) {
+	return []CPUStats{
+		{User: 600, System: 300, Idle: 4100, Total: 5000, Core: 0},
+		{User: 400, System: 200, Idle: 4400, Total: 5000, Core: 1},
+	}, nil
+}
+
+// readStat returns the mock aggregate and per-core statistics used by Sampler
+func readStat() (*CPUStats, []CPUStats, error) {
+	total, _ := GetCPU()
+	perCPU, _ := GetPerCPU()
+	return total, perCPU, nil
+}
+
 // CPUUsage represents more detailed CPU usage percentages
 type CPUUsage struct {
-	TotalPercent  float64 `json:"total_percent"`
-	UserPercent   float64 `json:"user_percent"`
-	SystemPercent float64 `json:"system_percent"`
-	IdlePercent   float64 `json:"idle_percent"`
+	Core             int     `json:"core"`
+	TotalPercent     float64 `json:"total_percent"`
+	UserPercent      float64 `json:"user_percent"`
+	SystemPercent    float64 `json:"system_percent"`
+	IdlePercent      float64 `json:"idle_percent"`
+	NicePercent      float64 `json:"nice_percent"`
+	IowaitPercent    float64 `json:"iowait_percent"`
+	IrqPercent       float64 `json:"irq_percent"`
+	SoftirqPercent   float64 `json:"softirq_percent"`
+	StealPercent     float64 `json:"steal_percent"`
+	GuestPercent     float64 `json:"guest_percent"`
+	GuestNicePercent float64 `json:"guest_nice_percent"`
 }
 
 // GetCPUUsage calculates mortality CPU usage statistics for Windows
 func GetCPUUsage() (*CPUUsage, error) {
 	// Mock usage for Windows
 	return &CPUUsage{
+		Core:          -1,
 		TotalPercent:  12.5,
 		UserPercent:   8.0,
 		SystemPercent: 4.5,
 		IdlePercent:   87.5,
 	}, nil
 }
-	
+
+// GetPerCPUUsage returns mock per-core CPU usage statistics for Windows
+func GetPerCPUUsage() ([]CPUUsage, error) {
+	return []CPUUsage{
+		{Core: 0, TotalPercent: 18.0, UserPercent: 12.0, SystemPercent: 6.0, IdlePercent: 82.0},
+		{Core: 1, TotalPercent: 7.0, UserPercent: 4.0, SystemPercent: 3.0, IdlePercent: 93.0},
+	}, nil
+}
@@ -0,0 +1,148 @@
+//go:build linux
+// +build linux
+
+package cpu
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// CPUFrequency represents the clock and cpufreq policy of a single core
+type CPUFrequency struct {
+	Core       int     `json:"core"`
+	CurrentMHz float64 `json:"current_mhz"`
+	// MinMHz and MaxMHz are the hardware limits; ScalingMinMHz and
+	// ScalingMaxMHz are the limits the governor is currently allowed to use
+	MinMHz             float64  `json:"min_mhz"`
+	MaxMHz             float64  `json:"max_mhz"`
+	ScalingMinMHz      float64  `json:"scaling_min_mhz"`
+	ScalingMaxMHz      float64  `json:"scaling_max_mhz"`
+	Governor           string   `json:"governor"`
+	AvailableGovernors []string `json:"available_governors"`
+	Driver             string   `json:"driver"`
+	// Source is "cpufreq" when read from sysfs, or "cpuinfo" when only
+	// the cpu MHz field of /proc/cpuinfo was available (common in VMs)
+	Source string `json:"source"`
+}
+
+// GetFrequencies returns the current frequency and cpufreq governor of every online core.
+// Cores without a cpufreq directory fall back to the cpu MHz field of /proc/cpuinfo,
+// and cores with neither are omitted. It is an error if no core has a frequency source.
+func GetFrequencies() ([]CPUFrequency, error) {
+	online, err := readCPUList(filepath.Join(sysCPUPath, "online"))
+	if err != nil {
+		return nil, err
+	}
+
+	var cpuinfoMHz map[int]float64
+	freqs := make([]CPUFrequency, 0, len(online))
+	for _, core := range online {
+		freq, ok := readCPUFreq(core)
+		if !ok {
+			if cpuinfoMHz == nil {
+				cpuinfoMHz, err = readCPUInfoMHz()
+				if err != nil {
+					return nil, err
+				}
+			}
+			mhz, ok := cpuinfoMHz[core]
+			if !ok {
+				// Some architectures have no cpu MHz field at all
+				continue
+			}
+			freq = CPUFrequency{
+				Core:       core,
+				CurrentMHz: mhz,
+				Source:     "cpuinfo",
+			}
+		}
+		freqs = append(freqs, freq)
+	}
+
+	if len(freqs) == 0 {
+		return nil, fmt.Errorf("no cpufreq or /proc/cpuinfo frequency available")
+	}
+
+	return freqs, nil
+}
+
+// readCPUFreq reads /sys/devices/system/cpu/cpuN/cpufreq, reporting false if it is absent
+func readCPUFreq(core int) (CPUFrequency, bool) {
+	dir := filepath.Join(sysCPUPath, fmt.Sprintf("cpu%d", core), "cpufreq")
+
+	cur, err := readKHz(filepath.Join(dir, "scaling_cur_freq"))
+	if err != nil {
+		return CPUFrequency{}, false
+	}
+
+	freq := CPUFrequency{
+		Core:       core,
+		CurrentMHz: cur,
+		Governor:   readString(filepath.Join(dir, "scaling_governor")),
+		Driver:     readString(filepath.Join(dir, "scaling_driver")),
+		Source:     "cpufreq",
+	}
+	freq.MinMHz, _ = readKHz(filepath.Join(dir, "cpuinfo_min_freq"))
+	freq.MaxMHz, _ = readKHz(filepath.Join(dir, "cpuinfo_max_freq"))
+	freq.ScalingMinMHz, _ = readKHz(filepath.Join(dir, "scaling_min_freq"))
+	freq.ScalingMaxMHz, _ = readKHz(filepath.Join(dir, "scaling_max_freq"))
+	freq.AvailableGovernors = strings.Fields(readString(filepath.Join(dir, "scaling_available_governors")))
+
+	return freq, true
+}
+
+// readKHz reads a cpufreq value, which sysfs reports in kHz, and converts it to MHz
+func readKHz(path string) (float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	khz, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return float64(khz) / 1000, nil
+}
+
+// readCPUInfoMHz maps each processor in /proc/cpuinfo to its cpu MHz field
+func readCPUInfoMHz() (map[int]float64, error) {
+	file, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	mhz := make(map[int]float64)
+	processor := -1
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		val := strings.TrimSpace(parts[1])
+
+		switch strings.TrimSpace(parts[0]) {
+		case "processor":
+			processor, err = strconv.Atoi(val)
+			if err != nil {
+				processor = -1
+			}
+		case "cpu MHz":
+			if processor >= 0 {
+				if f, err := strconv.ParseFloat(val, 64); err == nil {
+					mhz[processor] = f
+				}
+			}
+		}
+	}
+
+	return mhz, scanner.Err()
+}
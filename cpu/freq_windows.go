@@ -0,0 +1,28 @@
+//go:build windows
+// +build windows
+
+package cpu
+
+// CPUFrequency represents the clock and frequency policy of a single core
+type CPUFrequency struct {
+	Core       int     `json:"core"`
+	CurrentMHz float64 `json:"current_mhz"`
+	// MinMHz and MaxMHz are the hardware limits; ScalingMinMHz and
+	// ScalingMaxMHz are the limits the governor is currently allowed to use
+	MinMHz             float64  `json:"min_mhz"`
+	MaxMHz             float64  `json:"max_mhz"`
+	ScalingMinMHz      float64  `json:"scaling_min_mhz"`
+	ScalingMaxMHz      float64  `json:"scaling_max_mhz"`
+	Governor           string   `json:"governor"`
+	AvailableGovernors []string `json:"available_governors"`
+	Driver             string   `json:"driver"`
+	Source             string   `json:"source"`
+}
+
+// GetFrequencies returns mock per-core frequencies for Windows
+func GetFrequencies() ([]CPUFrequency, error) {
+	return []CPUFrequency{
+		{Core: 0, CurrentMHz: 2400, MinMHz: 800, MaxMHz: 3600, Source: "mock"},
+		{Core: 1, CurrentMHz: 2400, MinMHz: 800, MaxMHz: 3600, Source: "mock"},
+	}, nil
+}
@@ -0,0 +1,217 @@
+//go:build linux
+// +build linux
+
+package cpu
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CacheInfo describes one level of CPU cache from /sys/devices/system/cpu/cpu*/cache
+type CacheInfo struct {
+	Level     int    `json:"level"`
+	Type      string `json:"type"`
+	SizeBytes uint64 `json:"size_bytes"`
+	// Instances is the number of distinct caches of this kind across all CPUs
+	Instances int `json:"instances"`
+}
+
+// CPUInfo represents the CPU hardware inventory from /proc/cpuinfo and sysfs topology
+type CPUInfo struct {
+	ModelName      string      `json:"model_name"`
+	Vendor         string      `json:"vendor"`
+	Family         string      `json:"family"`
+	Model          string      `json:"model"`
+	Stepping       string      `json:"stepping"`
+	Microcode      string      `json:"microcode"`
+	Flags          []string    `json:"flags"`
+	Caches         []CacheInfo `json:"caches"`
+	Sockets        int         `json:"sockets"`
+	PhysicalCores  int         `json:"physical_cores"`
+	ThreadsPerCore int         `json:"threads_per_core"`
+	LogicalCPUs    int         `json:"logical_cpus"`
+	Online         []int       `json:"online"`
+	Offline        []int       `json:"offline"`
+}
+
+const sysCPUPath = "/sys/devices/system/cpu"
+
+// GetInfo returns the CPU model, feature flags, caches and topology of the machine
+func GetInfo() (*CPUInfo, error) {
+	info := &CPUInfo{}
+	if err := parseCPUInfo(info); err != nil {
+		return nil, err
+	}
+
+	var err error
+	info.Online, err = readCPUList(filepath.Join(sysCPUPath, "online"))
+	if err != nil {
+		return nil, err
+	}
+	// The offline file is absent on kernels without CPU hotplug
+	info.Offline, _ = readCPUList(filepath.Join(sysCPUPath, "offline"))
+	info.LogicalCPUs = len(info.Online)
+
+	packages := make(map[int]bool)
+	cores := make(map[[2]int]bool)
+	for _, cpu := range info.Online {
+		topology := filepath.Join(sysCPUPath, fmt.Sprintf("cpu%d", cpu), "topology")
+		pkg, err := readInt(filepath.Join(topology, "physical_package_id"))
+		if err != nil {
+			continue
+		}
+		core, err := readInt(filepath.Join(topology, "core_id"))
+		if err != nil {
+			continue
+		}
+		packages[pkg] = true
+		cores[[2]int{pkg, core}] = true
+	}
+	info.Sockets = len(packages)
+	info.PhysicalCores = len(cores)
+	if info.PhysicalCores > 0 {
+		info.ThreadsPerCore = info.LogicalCPUs / info.PhysicalCores
+	}
+
+	info.Caches = readCaches(info.Online)
+
+	return info, nil
+}
+
+// parseCPUInfo fills the model fields from the first processor block of /proc/cpuinfo
+func parseCPUInfo(info *CPUInfo) error {
+	file, err := os.Open("/proc/cpuinfo")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" && info.Vendor != "" {
+			// All processors share the same model; the first block is enough
+			break
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(parts[0])
+		val := strings.TrimSpace(parts[1])
+
+		switch key {
+		case "model name", "Processor":
+			info.ModelName = val
+		case "vendor_id", "CPU implementer":
+			info.Vendor = val
+		case "cpu family", "CPU architecture":
+			info.Family = val
+		case "model", "CPU part":
+			info.Model = val
+		case "stepping", "CPU revision":
+			info.Stepping = val
+		case "microcode":
+			info.Microcode = val
+		case "flags", "Features":
+			info.Flags = strings.Fields(val)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// readCaches collects the distinct caches of the online CPUs
+func readCaches(online []int) []CacheInfo {
+	type cacheKey struct {
+		level int
+		kind  string
+	}
+	caches := make(map[cacheKey]*CacheInfo)
+	shared := make(map[cacheKey]map[string]bool)
+
+	for _, cpu := range online {
+		dirs, _ := filepath.Glob(filepath.Join(sysCPUPath, fmt.Sprintf("cpu%d", cpu), "cache", "index*"))
+		for _, dir := range dirs {
+			level, err := readInt(filepath.Join(dir, "level"))
+			if err != nil {
+				continue
+			}
+			key := cacheKey{level: level, kind: readString(filepath.Join(dir, "type"))}
+
+			c, ok := caches[key]
+			if !ok {
+				c = &CacheInfo{Level: key.level, Type: key.kind}
+				c.SizeBytes, _ = parseSize(readString(filepath.Join(dir, "size")))
+				caches[key] = c
+				shared[key] = make(map[string]bool)
+			}
+			// CPUs sharing a cache list the same shared_cpu_list
+			shared[key][readString(filepath.Join(dir, "shared_cpu_list"))] = true
+			c.Instances = len(shared[key])
+		}
+	}
+
+	result := make([]CacheInfo, 0, len(caches))
+	for _, c := range caches {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Level != result[j].Level {
+			return result[i].Level < result[j].Level
+		}
+		return result[i].Type < result[j].Type
+	})
+
+	return result
+}
+
+func readCPUList(path string) ([]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseList(string(data))
+}
+
+func readString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func readInt(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// parseSize converts sysfs sizes such as "32K" or "2048K" to bytes
+func parseSize(s string) (uint64, error) {
+	multiplier := uint64(1)
+	switch {
+	case strings.HasSuffix(s, "K"):
+		multiplier = 1024
+	case strings.HasSuffix(s, "M"):
+		multiplier = 1024 * 1024
+	case strings.HasSuffix(s, "G"):
+		multiplier = 1024 * 1024 * 1024
+	}
+	val, err := strconv.ParseUint(strings.TrimRight(s, "KMG"), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return val * multiplier, nil
+}
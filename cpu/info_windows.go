@@ -0,0 +1,46 @@
+//go:build windows
+// +build windows
+
+package cpu
+
+// CacheInfo describes one level of CPU cache
+type CacheInfo struct {
+	Level     int    `json:"level"`
+	Type      string `json:"type"`
+	SizeBytes uint64 `json:"size_bytes"`
+	// Instances is the number of distinct caches of this kind across all CPUs
+	Instances int `json:"instances"`
+}
+
+// CPUInfo represents the CPU hardware inventory
+type CPUInfo struct {
+	ModelName      string      `json:"model_name"`
+	Vendor         string      `json:"vendor"`
+	Family         string      `json:"family"`
+	Model          string      `json:"model"`
+	Stepping       string      `json:"stepping"`
+	Microcode      string      `json:"microcode"`
+	Flags          []string    `json:"flags"`
+	Caches         []CacheInfo `json:"caches"`
+	Sockets        int         `json:"sockets"`
+	PhysicalCores  int         `json:"physical_cores"`
+	ThreadsPerCore int         `json:"threads_per_core"`
+	LogicalCPUs    int         `json:"logical_cpus"`
+	Online         []int       `json:"online"`
+	Offline        []int       `json:"offline"`
+}
+
+// GetInfo returns mock CPU inventory for Windows
+func GetInfo() (*CPUInfo, error) {
+	return &CPUInfo{
+		ModelName:      "Mock CPU",
+		Vendor:         "GenuineIntel",
+		Flags:          []string{"sse", "sse2", "avx"},
+		Caches:         []CacheInfo{{Level: 1, Type: "Data", SizeBytes: 32 * 1024, Instances: 1}},
+		Sockets:        1,
+		PhysicalCores:  1,
+		ThreadsPerCore: 2,
+		LogicalCPUs:    2,
+		Online:         []int{0, 1},
+	}, nil
+}
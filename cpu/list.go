@@ -0,0 +1,36 @@
+package cpu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseList parses a kernel CPU list such as "0-3,8,10-11" into CPU indexes
+func ParseList(s string) ([]int, error) {
+	cpus := []int{}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return cpus, nil
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		bounds := strings.SplitN(part, "-", 2)
+		start, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpu list %q", s)
+		}
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.Atoi(bounds[1])
+			if err != nil || end < start {
+				return nil, fmt.Errorf("invalid cpu list %q", s)
+			}
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+
+	return cpus, nil
+}
@@ -0,0 +1,46 @@
+package cpu
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{input: "", want: []int{}},
+		{input: "\n", want: []int{}},
+		{input: "0", want: []int{0}},
+		{input: "0-3", want: []int{0, 1, 2, 3}},
+		{input: "0-3,8-11", want: []int{0, 1, 2, 3, 8, 9, 10, 11}},
+		{input: "0,2,4", want: []int{0, 2, 4}},
+		{input: "0-1,4,6-7\n", want: []int{0, 1, 4, 6, 7}},
+		{input: "5-5", want: []int{5}},
+		{input: "3-1", wantErr: true},
+		{input: "0-", wantErr: true},
+		{input: "-3", wantErr: true},
+		{input: "0,,1", wantErr: true},
+		{input: "a-b", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseList(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseList(%q) = %v, want an error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseList(%q) unexpected error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseList(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package cpu
+
+import (
+	"time"
+
+	"github.com/avirooppal/gosysutil/sampler"
+)
+
+// statSample is one reading of the aggregate and per-core cpu lines
+type statSample struct {
+	total  *CPUStats
+	perCPU []CPUStats
+}
+
+// usageSample is the usage computed between two statSamples
+type usageSample struct {
+	total  *CPUUsage
+	perCPU []CPUUsage
+}
+
+// Sampler reads CPU statistics in the background on a fixed interval and
+// serves usage percentages computed from the last two samples, so callers
+// never block waiting for a measurement window.
+type Sampler struct {
+	s *sampler.Sampler[statSample, usageSample]
+}
+
+// NewSampler returns a Sampler that reads /proc/stat every interval.
+// Call Start to begin sampling.
+func NewSampler(interval time.Duration) *Sampler {
+	return &Sampler{s: sampler.New(interval, readStatSample, calculateUsageSample)}
+}
+
+// Start takes an initial sample and begins sampling in the background.
+// Usage is available once the first interval has elapsed.
+// Calling Start more than once has no effect.
+func (s *Sampler) Start() error {
+	return s.s.Start()
+}
+
+// Stop ends background sampling. The last computed usage remains available.
+func (s *Sampler) Stop() {
+	s.s.Stop()
+}
+
+// Usage returns the aggregate CPU usage over the last interval, or
+// sampler.ErrNotReady until the second sample has been taken
+func (s *Sampler) Usage() (*CPUUsage, error) {
+	usage, err := s.s.Value()
+	if err != nil {
+		return nil, err
+	}
+	total := *usage.total
+	return &total, nil
+}
+
+// PerCPU returns the usage of every core over the last interval, or
+// sampler.ErrNotReady until the second sample has been taken
+func (s *Sampler) PerCPU() ([]CPUUsage, error) {
+	usage, err := s.s.Value()
+	if err != nil {
+		return nil, err
+	}
+	return append([]CPUUsage{}, usage.perCPU...), nil
+}
+
+func readStatSample() (statSample, error) {
+	total, perCPU, err := readStat()
+	return statSample{total: total, perCPU: perCPU}, err
+}
+
+func calculateUsageSample(prev, cur statSample) usageSample {
+	return usageSample{
+		total:  calculateUsage(prev.total, cur.total),
+		perCPU: calculatePerCPUUsage(prev.perCPU, cur.perCPU),
+	}
+}
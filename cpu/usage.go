@@ -0,0 +1,57 @@
+package cpu
+
+// calculateUsage converts the delta between two samples of the same CPU into percentages.
+// User and nice exclude the guest time the kernel folds into them, so the
+// breakdown sums to 100.
+func calculateUsage(s1, s2 *CPUStats) *CPUUsage {
+	usage := &CPUUsage{Core: s2.Core}
+
+	totalDelta := delta(s1.Total, s2.Total)
+	if totalDelta == 0 {
+		return usage
+	}
+
+	percent := func(prev, cur uint64) float64 {
+		return float64(delta(prev, cur)) / float64(totalDelta) * 100
+	}
+
+	usage.UserPercent = percent(s1.User-s1.Guest, s2.User-s2.Guest)
+	usage.NicePercent = percent(s1.Nice-s1.GuestNice, s2.Nice-s2.GuestNice)
+	usage.SystemPercent = percent(s1.System, s2.System)
+	usage.IdlePercent = percent(s1.Idle, s2.Idle)
+	usage.IowaitPercent = percent(s1.Iowait, s2.Iowait)
+	usage.IrqPercent = percent(s1.Irq, s2.Irq)
+	usage.SoftirqPercent = percent(s1.Softirq, s2.Softirq)
+	usage.StealPercent = percent(s1.Steal, s2.Steal)
+	usage.GuestPercent = percent(s1.Guest, s2.Guest)
+	usage.GuestNicePercent = percent(s1.GuestNice, s2.GuestNice)
+	usage.TotalPercent = 100 - usage.IdlePercent
+
+	return usage
+}
+
+// calculatePerCPUUsage matches two per-core samples by core index.
+// Cores missing from either sample (hotplugged in or out) are omitted.
+func calculatePerCPUUsage(s1, s2 []CPUStats) []CPUUsage {
+	prev := make(map[int]*CPUStats, len(s1))
+	for i := range s1 {
+		prev[s1[i].Core] = &s1[i]
+	}
+
+	usage := make([]CPUUsage, 0, len(s2))
+	for i := range s2 {
+		if p, ok := prev[s2[i].Core]; ok {
+			usage = append(usage, *calculateUsage(p, &s2[i]))
+		}
+	}
+
+	return usage
+}
+
+// delta returns cur - prev, or 0 if the counter went backwards (e.g. after a CPU was hotplugged)
+func delta(prev, cur uint64) uint64 {
+	if cur < prev {
+		return 0
+	}
+	return cur - prev
+}
@@ -0,0 +1,105 @@
+//go:build linux
+// +build linux
+
+package disk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DeviceInfo represents the static attributes of a block device from /sys/block/<dev>
+type DeviceInfo struct {
+	Name string `json:"name"`
+	// Size is in bytes
+	Size uint64 `json:"size"`
+	// Model, Vendor and Serial are empty when the driver does not expose them
+	Model     string `json:"model"`
+	Vendor    string `json:"vendor"`
+	Serial    string `json:"serial"`
+	Removable bool   `json:"removable"`
+	ReadOnly  bool   `json:"read_only"`
+
+	Rotational        bool   `json:"rotational"`
+	LogicalBlockSize  uint64 `json:"logical_block_size"`
+	PhysicalBlockSize uint64 `json:"physical_block_size"`
+	// Scheduler is the active I/O scheduler, e.g. "mq-deadline" or "none"
+	Scheduler           string   `json:"scheduler"`
+	AvailableSchedulers []string `json:"available_schedulers"`
+	NrRequests          uint64   `json:"nr_requests"`
+	ReadAheadKB         uint64   `json:"read_ahead_kb"`
+}
+
+const sysBlockPath = "/sys/block"
+
+// GetDeviceInfo returns the size, identity and queue settings of a whole block
+// device such as "sda" or "nvme0n1". Partitions are not listed in /sys/block.
+func GetDeviceInfo(name string) (*DeviceInfo, error) {
+	if name == "" || strings.ContainsRune(name, '/') || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid block device name %q: %w", name, os.ErrNotExist)
+	}
+	dir := filepath.Join(sysBlockPath, name)
+
+	// size is always in 512-byte sectors
+	sectors, err := readSysUint(filepath.Join(dir, "size"))
+	if err != nil {
+		return nil, err
+	}
+
+	info := &DeviceInfo{
+		Name:   name,
+		Size:   sectors * sectorSize,
+		Model:  readSysString(filepath.Join(dir, "device/model")),
+		Vendor: readSysString(filepath.Join(dir, "device/vendor")),
+	}
+
+	// SCSI and NVMe expose the serial on the device, virtio on the disk itself
+	info.Serial = readSysString(filepath.Join(dir, "device/serial"))
+	if info.Serial == "" {
+		info.Serial = readSysString(filepath.Join(dir, "serial"))
+	}
+
+	info.Removable = readSysString(filepath.Join(dir, "removable")) == "1"
+	info.ReadOnly = readSysString(filepath.Join(dir, "ro")) == "1"
+
+	queue := filepath.Join(dir, "queue")
+	info.Rotational = readSysString(filepath.Join(queue, "rotational")) == "1"
+	info.LogicalBlockSize, _ = readSysUint(filepath.Join(queue, "logical_block_size"))
+	info.PhysicalBlockSize, _ = readSysUint(filepath.Join(queue, "physical_block_size"))
+	info.NrRequests, _ = readSysUint(filepath.Join(queue, "nr_requests"))
+	info.ReadAheadKB, _ = readSysUint(filepath.Join(queue, "read_ahead_kb"))
+
+	// "none [mq-deadline] kyber bfq"; devices without a queue report just "none"
+	for _, s := range strings.Fields(readSysString(filepath.Join(queue, "scheduler"))) {
+		if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+			s = strings.Trim(s, "[]")
+			info.Scheduler = s
+		}
+		info.AvailableSchedulers = append(info.AvailableSchedulers, s)
+	}
+	if info.Scheduler == "" && len(info.AvailableSchedulers) == 1 {
+		info.Scheduler = info.AvailableSchedulers[0]
+	}
+
+	return info, nil
+}
+
+// readSysString returns the trimmed content of a sysfs attribute, or "" if it cannot be read
+func readSysString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func readSysUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
@@ -0,0 +1,35 @@
+//go:build windows
+// +build windows
+
+package disk
+
+// DeviceInfo represents the static attributes of a block device
+type DeviceInfo struct {
+	Name      string `json:"name"`
+	Size      uint64 `json:"size"`
+	Model     string `json:"model"`
+	Vendor    string `json:"vendor"`
+	Serial    string `json:"serial"`
+	Removable bool   `json:"removable"`
+	ReadOnly  bool   `json:"read_only"`
+
+	Rotational          bool     `json:"rotational"`
+	LogicalBlockSize    uint64   `json:"logical_block_size"`
+	PhysicalBlockSize   uint64   `json:"physical_block_size"`
+	Scheduler           string   `json:"scheduler"`
+	AvailableSchedulers []string `json:"available_schedulers"`
+	NrRequests          uint64   `json:"nr_requests"`
+	ReadAheadKB         uint64   `json:"read_ahead_kb"`
+}
+
+// GetDeviceInfo returns mock device information for Windows
+func GetDeviceInfo(name string) (*DeviceInfo, error) {
+	return &DeviceInfo{
+		Name:              name,
+		Size:              256 * 1024 * 1024 * 1024,
+		Model:             "Mock Disk",
+		LogicalBlockSize:  512,
+		PhysicalBlockSize: 4096,
+		Scheduler:         "none",
+	}, nil
+}
@@ -1,3 +1,4 @@
+//go:build linux
 // +build linux
 
 package disk
@@ -5,13 +6,23 @@ package disk
 import (
 	"bufio"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // DiskStats represents disk I/O statistics from /proc/diskstats
 type DiskStats struct {
-	Name            string
+	Name  string
+	Major uint32
+	Minor uint32
+	Type  DeviceType
+	// Parent is the whole disk a partition belongs to, empty for other types
+	Parent string
+	// Rotational is true for spinning disks; partitions inherit it from their parent
+	Rotational bool
+
 	ReadsCompleted  uint64
 	ReadsMerged     uint64
 	SectorsRead     uint64
@@ -23,62 +34,77 @@ type DiskStats struct {
 	IoInProgress    uint64
 	IoTime          uint64
 	WeightedIoTime  uint64
+	// Discard counters, present since Linux 4.18 (Fields >= FieldsDiscard)
+	DiscardsCompleted uint64
+	DiscardsMerged    uint64
+	SectorsDiscarded  uint64
+	DiscardTime       uint64
+	// Flush counters, present since Linux 5.5 (Fields >= FieldsFlush)
+	FlushesCompleted uint64
+	FlushTime        uint64
+	// Fields is the number of statistics columns the kernel provided for the device
+	Fields int
+	// Timestamp is when the counters were read, for computing rates between samples
+	Timestamp time.Time
 }
 
-// GetDisk returns disk I/O statistics for all disks found in /proc/diskstats
-func GetDisk() ([]DiskStats, error) {
+const sysClassBlockPath = "/sys/class/block"
+
+// GetDisk returns disk I/O statistics for the devices in /proc/diskstats that
+// pass every filter. Without filters, DefaultFilter is applied.
+func GetDisk(filters ...Filter) ([]DiskStats, error) {
 	file, err := os.Open("/proc/diskstats")
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
+	now := time.Now()
 	var diskStats []DiskStats
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
-		line := scanner.Text()
-		fields := strings.Fields(line)
-        // Expected at least 14 fields usually, but let's be safe with 7+ to get basics
-        // Major, Minor, Name, ... stats ...
+		// Major, Minor, Name, ... stats ...
+		fields := strings.Fields(scanner.Text())
 		if len(fields) < 7 {
-            continue
+			continue
 		}
-        
-        name := fields[2]
-        // Filter loop devices and ram devices if desired, but "plug and play" might want all.
-        // Usually we want physical disks like sda, nvme0n1, vda.
-        // Simple filter: skip if name starts with "loop" or "ram"
-        if strings.HasPrefix(name, "loop") || strings.HasPrefix(name, "ram") {
-            continue
-        }
-        
-        // Also skip partitions (like sda1) to avoid double counting if user sums
-        // A common heuristic is to check if it ends in a number, but nvme0n1 is a disk...
-        // Let's just include everything for now, or let user filter.
-        // Actually, for "plug and play", filtering partitions is usually helpful but risky if we miss something.
-        // Let's keep it raw but maybe exclude obvious partitions like sda1 if sda exists? 
-        // No, standard tools usually just dump everything or filter by type.
-        // Let's minimal filter: loop/ram/sr (optical)
-         if strings.HasPrefix(name, "sr") {
-            continue
-        }
 
 		stats := DiskStats{
-            Name: name,
-        }
-        
-        // Fields start at index 3 for the first stat
-        if len(fields) > 3 { stats.ReadsCompleted, _ = strconv.ParseUint(fields[3], 10, 64) }
-        if len(fields) > 4 { stats.ReadsMerged, _ = strconv.ParseUint(fields[4], 10, 64) }
-        if len(fields) > 5 { stats.SectorsRead, _ = strconv.ParseUint(fields[5], 10, 64) }
-        if len(fields) > 6 { stats.ReadTime, _ = strconv.ParseUint(fields[6], 10, 64) }
-        if len(fields) > 7 { stats.WritesCompleted, _ = strconv.ParseUint(fields[7], 10, 64) }
-        if len(fields) > 8 { stats.WritesMerged, _ = strconv.ParseUint(fields[8], 10, 64) }
-        if len(fields) > 9 { stats.SectorsWritten, _ = strconv.ParseUint(fields[9], 10, 64) }
-        if len(fields) > 10 { stats.WriteTime, _ = strconv.ParseUint(fields[10], 10, 64) }
-        if len(fields) > 11 { stats.IoInProgress, _ = strconv.ParseUint(fields[11], 10, 64) }
-        if len(fields) > 12 { stats.IoTime, _ = strconv.ParseUint(fields[12], 10, 64) }
-        if len(fields) > 13 { stats.WeightedIoTime, _ = strconv.ParseUint(fields[13], 10, 64) }
+			Name:      fields[2],
+			Fields:    len(fields) - 3,
+			Timestamp: now,
+		}
+		major, _ := strconv.ParseUint(fields[0], 10, 32)
+		minor, _ := strconv.ParseUint(fields[1], 10, 32)
+		stats.Major, stats.Minor = uint32(major), uint32(minor)
+		stats.Type = classify(stats.Name, stats.Major)
+
+		// Counters follow major, minor and name in kernel order; older kernels stop early
+		counters := []*uint64{
+			&stats.ReadsCompleted, &stats.ReadsMerged, &stats.SectorsRead, &stats.ReadTime,
+			&stats.WritesCompleted, &stats.WritesMerged, &stats.SectorsWritten, &stats.WriteTime,
+			&stats.IoInProgress, &stats.IoTime, &stats.WeightedIoTime,
+			&stats.DiscardsCompleted, &stats.DiscardsMerged, &stats.SectorsDiscarded, &stats.DiscardTime,
+			&stats.FlushesCompleted, &stats.FlushTime,
+		}
+		for i, field := range fields[3:] {
+			if i >= len(counters) {
+				break
+			}
+			*counters[i], _ = strconv.ParseUint(field, 10, 64)
+		}
+
+		if !matches(&stats, filters) {
+			continue
+		}
+
+		// Only the devices that are kept pay for the remaining sysfs reads
+		rotationalDisk := stats.Name
+		if stats.Type == TypePartition {
+			stats.Parent = parentDisk(stats.Name)
+			rotationalDisk = stats.Parent
+		}
+		stats.Rotational = readSysString(filepath.Join(sysBlockPath, rotationalDisk, "queue/rotational")) == "1"
 
 		diskStats = append(diskStats, stats)
 	}
@@ -89,3 +115,60 @@ func GetDisk() ([]DiskStats, error) {
 
 	return diskStats, nil
 }
+
+// Block device majors that are fixed by the kernel; dm, zram and extended
+// partition numbers are allocated dynamically and are detected through sysfs
+const (
+	ramdiskMajor = 1
+	loopMajor    = 7
+	mdMajor      = 9
+	scsiCDMajor  = 11
+	nbdMajor     = 43
+)
+
+// classify returns the type of a block device from its sysfs attributes and major number
+func classify(name string, major uint32) DeviceType {
+	dir := filepath.Join(sysClassBlockPath, name)
+	exists := func(attr string) bool {
+		_, err := os.Stat(filepath.Join(dir, attr))
+		return err == nil
+	}
+
+	// Partitions have a "partition" attribute whatever the type of their disk
+	if exists("partition") {
+		return TypePartition
+	}
+
+	switch major {
+	case loopMajor:
+		return TypeLoop
+	case ramdiskMajor:
+		return TypeRAM
+	case scsiCDMajor:
+		return TypeOptical
+	case nbdMajor:
+		return TypeNBD
+	case mdMajor:
+		return TypeMD
+	}
+
+	switch {
+	case exists("dm"):
+		return TypeDM
+	case exists("md"):
+		// md arrays created with a dynamic major (mdp partitionable arrays)
+		return TypeMD
+	case exists("comp_algorithm"):
+		return TypeZram
+	}
+	return TypeDisk
+}
+
+// parentDisk returns the whole disk a partition lives under in the device tree
+func parentDisk(name string) string {
+	target, err := filepath.EvalSymlinks(filepath.Join(sysClassBlockPath, name))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(filepath.Dir(target))
+}
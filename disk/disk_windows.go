@@ -2,9 +2,17 @@
 
 package disk
 
+import "time"
+
 // DiskStats represents disk I/O statistics
 type DiskStats struct {
-	Name            string
+	Name       string
+	Major      uint32
+	Minor      uint32
+	Type       DeviceType
+	Parent     string
+	Rotational bool
+
 	ReadsCompleted  uint64
 	ReadsMerged     uint64
 	SectorsRead     uint64
@@ -16,11 +24,24 @@ type DiskStats struct {
 	IoInProgress    uint64
 	IoTime          uint64
 	WeightedIoTime  uint64
+
+	DiscardsCompleted uint64
+	DiscardsMerged    uint64
+	SectorsDiscarded  uint64
+	DiscardTime       uint64
+	FlushesCompleted  uint64
+	FlushTime         uint64
+	Fields            int
+
+	Timestamp time.Time
 }
 
 // GetDisk returns mock disk statistics for Windows
-func GetDisk() ([]DiskStats, error) {
-	return []DiskStats{
-		{Name: "C:", ReadsCompleted: 5000, WritesCompleted: 2000},
-	}, nil
+func GetDisk(filters ...Filter) ([]DiskStats, error) {
+	var stats []DiskStats
+	d := DiskStats{Name: "C:", Type: TypeDisk, ReadsCompleted: 5000, WritesCompleted: 2000, Fields: FieldsBase, Timestamp: time.Now()}
+	if matches(&d, filters) {
+		stats = append(stats, d)
+	}
+	return stats, nil
 }
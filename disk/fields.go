@@ -0,0 +1,21 @@
+package disk
+
+// Number of statistics columns per device in /proc/diskstats, as reported in DiskStats.Fields
+const (
+	// FieldsBase is the read, write and I/O time set every 2.6+ kernel provides
+	FieldsBase = 11
+	// FieldsDiscard adds the discard counters (Linux 4.18+)
+	FieldsDiscard = 15
+	// FieldsFlush adds the flush counters (Linux 5.5+)
+	FieldsFlush = 17
+)
+
+// HasDiscard reports whether the kernel provided the discard counters
+func (d *DiskStats) HasDiscard() bool {
+	return d.Fields >= FieldsDiscard
+}
+
+// HasFlush reports whether the kernel provided the flush counters
+func (d *DiskStats) HasFlush() bool {
+	return d.Fields >= FieldsFlush
+}
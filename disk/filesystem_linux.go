@@ -0,0 +1,264 @@
+//go:build linux
+// +build linux
+
+package disk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Filesystem represents the capacity and inode usage of one mounted filesystem
+type Filesystem struct {
+	Device     string   `json:"device"`
+	Mountpoint string   `json:"mountpoint"`
+	FSType     string   `json:"fstype"`
+	Options    []string `json:"options"`
+	// Major and Minor identify the backing device, as in /proc/diskstats
+	Major uint32 `json:"major"`
+	Minor uint32 `json:"minor"`
+	// Remote is true for network and FUSE filesystems, whose statfs(2)
+	// depends on a server or daemon answering
+	Remote bool `json:"remote"`
+
+	// Sizes in bytes. Free includes blocks reserved for root; Available does not.
+	Total     uint64 `json:"total"`
+	Used      uint64 `json:"used"`
+	Free      uint64 `json:"free"`
+	Available uint64 `json:"available"`
+	// UsedPercent is Used / (Used + Available), matching the Use% column of df
+	UsedPercent float64 `json:"used_percent"`
+
+	Inodes            uint64  `json:"inodes"`
+	InodesUsed        uint64  `json:"inodes_used"`
+	InodesFree        uint64  `json:"inodes_free"`
+	InodesUsedPercent float64 `json:"inodes_used_percent"`
+}
+
+// pseudoFilesystems have no backing storage and are skipped by GetFilesystems.
+// tmpfs and devtmpfs are kept, since filling them exhausts memory.
+var pseudoFilesystems = map[string]bool{
+	"autofs": true, "binfmt_misc": true, "bpf": true, "cgroup": true, "cgroup2": true,
+	"configfs": true, "debugfs": true, "devpts": true, "efivarfs": true, "fusectl": true,
+	"hugetlbfs": true, "mqueue": true, "nsfs": true, "proc": true, "pstore": true,
+	"rpc_pipefs": true, "securityfs": true, "selinuxfs": true, "sysfs": true, "tracefs": true,
+}
+
+// remoteFilesystems are network filesystems; every fuse* type is treated as remote too
+var remoteFilesystems = map[string]bool{
+	"9p": true, "afs": true, "ceph": true, "cifs": true, "glusterfs": true, "lustre": true,
+	"ncpfs": true, "nfs": true, "nfs4": true, "smb3": true, "smbfs": true, "sshfs": true,
+}
+
+// remoteStatTimeout bounds statfs(2) on remote filesystems, which blocks for as
+// long as the server is unreachable
+const remoteStatTimeout = 2 * time.Second
+
+// pendingStatfs holds the mountpoints whose statfs(2) has timed out and not yet
+// returned, so a hung mount ties up at most one goroutine
+var pendingStatfs sync.Map
+
+// mount is one line of /proc/self/mountinfo
+type mount struct {
+	fs    Filesystem
+	devID string // major:minor
+	root  string // path within the filesystem that is mounted
+}
+
+// GetFilesystems returns the usage of the mounted filesystems in
+// /proc/self/mountinfo that pass every filter, like df. Without filters,
+// LocalFilesystems is applied. Pseudo filesystems and filesystems without
+// blocks are skipped, mounts hidden by a later mount on the same path are
+// dropped, and a device mounted more than once (bind mounts) is reported once,
+// preferring the mount of its root.
+func GetFilesystems(filters ...FilesystemFilter) ([]Filesystem, error) {
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	mounts, err := parseMountInfo(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var filesystems []Filesystem
+	for _, m := range selectMounts(mounts) {
+		fs := m.fs
+		if !matchesFilesystem(&fs, filters) {
+			continue
+		}
+
+		statfs := statFilesystem
+		if fs.Remote {
+			statfs = statRemoteFilesystem
+		}
+		if err := statfs(&fs); err != nil || fs.Total == 0 {
+			continue
+		}
+		filesystems = append(filesystems, fs)
+	}
+
+	return filesystems, nil
+}
+
+// selectMounts drops mounts hidden by a later mount on the same path and keeps
+// one mount per device: its root mount if any, else the first
+func selectMounts(mounts []mount) []mount {
+	// The last mount on a path shadows the earlier ones
+	visible := make(map[string]int, len(mounts))
+	for i, m := range mounts {
+		visible[m.fs.Mountpoint] = i
+	}
+
+	chosen := make(map[string]int)
+	for i, m := range mounts {
+		if visible[m.fs.Mountpoint] != i {
+			continue
+		}
+		prev, ok := chosen[m.devID]
+		if !ok || (mounts[prev].root != "/" && m.root == "/") {
+			chosen[m.devID] = i
+		}
+	}
+
+	var selected []mount
+	for i, m := range mounts {
+		if chosen[m.devID] == i && visible[m.fs.Mountpoint] == i {
+			selected = append(selected, m)
+		}
+	}
+	return selected
+}
+
+// parseMountInfo parses /proc/self/mountinfo content, skipping pseudo filesystems
+func parseMountInfo(r io.Reader) ([]mount, error) {
+	var mounts []mount
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+		fields := strings.Fields(scanner.Text())
+		sep := -1
+		for i := 6; i < len(fields); i++ {
+			if fields[i] == "-" {
+				sep = i
+				break
+			}
+		}
+		if sep < 0 || len(fields) < sep+3 {
+			continue
+		}
+
+		fstype := fields[sep+1]
+		if pseudoFilesystems[fstype] {
+			continue
+		}
+
+		m := mount{
+			fs: Filesystem{
+				Device:     unescapeMountField(fields[sep+2]),
+				Mountpoint: unescapeMountField(fields[4]),
+				FSType:     fstype,
+				Options:    strings.Split(fields[5], ","),
+				Remote:     remoteFilesystems[fstype] || strings.HasPrefix(fstype, "fuse"),
+			},
+			devID: fields[2],
+			root:  unescapeMountField(fields[3]),
+		}
+		if major, minor, ok := strings.Cut(m.devID, ":"); ok {
+			maj, _ := strconv.ParseUint(major, 10, 32)
+			min, _ := strconv.ParseUint(minor, 10, 32)
+			m.fs.Major, m.fs.Minor = uint32(maj), uint32(min)
+		}
+
+		mounts = append(mounts, m)
+	}
+
+	return mounts, scanner.Err()
+}
+
+// statRemoteFilesystem runs statFilesystem with remoteStatTimeout. A call that
+// times out keeps running in the background, and the mount is skipped until it returns.
+func statRemoteFilesystem(fs *Filesystem) error {
+	mountpoint := fs.Mountpoint
+	if _, busy := pendingStatfs.LoadOrStore(mountpoint, struct{}{}); busy {
+		return fmt.Errorf("statfs %s: previous call has not returned", mountpoint)
+	}
+
+	result := *fs
+	done := make(chan error, 1)
+	go func() {
+		err := statFilesystem(&result)
+		pendingStatfs.Delete(mountpoint)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			*fs = result
+		}
+		return err
+	case <-time.After(remoteStatTimeout):
+		return fmt.Errorf("statfs %s: timed out", mountpoint)
+	}
+}
+
+// statFilesystem fills the capacity and inode fields of fs from statfs(2)
+func statFilesystem(fs *Filesystem) error {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(fs.Mountpoint, &st); err != nil {
+		return err
+	}
+
+	blockSize := uint64(st.Frsize)
+	if blockSize == 0 {
+		blockSize = uint64(st.Bsize)
+	}
+
+	fs.Total = st.Blocks * blockSize
+	fs.Free = st.Bfree * blockSize
+	fs.Available = st.Bavail * blockSize
+	fs.Used = fs.Total - fs.Free
+	if fs.Used+fs.Available > 0 {
+		fs.UsedPercent = float64(fs.Used) / float64(fs.Used+fs.Available) * 100
+	}
+
+	fs.Inodes = st.Files
+	fs.InodesFree = st.Ffree
+	fs.InodesUsed = st.Files - st.Ffree
+	if fs.Inodes > 0 {
+		fs.InodesUsedPercent = float64(fs.InodesUsed) / float64(fs.Inodes) * 100
+	}
+
+	return nil
+}
+
+// unescapeMountField decodes the octal escapes (\040 for space, \011, \012, \134)
+// the kernel uses in mountinfo paths
+func unescapeMountField(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if v, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(v))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,139 @@
+//go:build linux
+// +build linux
+
+package disk
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnescapeMountField(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "/mnt/data", want: "/mnt/data"},
+		{input: `/mnt/my\040photos`, want: "/mnt/my photos"},
+		{input: `/mnt/tab\011here`, want: "/mnt/tab\there"},
+		{input: `/mnt/new\012line`, want: "/mnt/new\nline"},
+		{input: `/mnt/back\134slash`, want: `/mnt/back\slash`},
+		{input: `/mnt/end\040`, want: "/mnt/end "},
+		{input: `\040\040`, want: "  "},
+		// Not a valid escape: left as it is
+		{input: `/mnt/bad\9xy`, want: `/mnt/bad\9xy`},
+		{input: `/mnt/short\04`, want: `/mnt/short\04`},
+	}
+
+	for _, tt := range tests {
+		if got := unescapeMountField(tt.input); got != tt.want {
+			t.Errorf("unescapeMountField(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseMountInfo(t *testing.T) {
+	input := `22 1 252:1 / / rw,relatime shared:1 - ext4 /dev/vda1 rw
+23 22 0:21 / /proc rw,nosuid shared:12 - proc proc rw
+24 22 0:5 / /dev rw,nosuid shared:2 - devtmpfs udev rw,size=4001012k
+35 22 0:50 / /mnt/my\040photos rw,relatime shared:40 - nfs4 server:/export\040dir rw,vers=4.2
+36 22 0:51 / /home/user/remote rw,nosuid,nodev - fuse.sshfs user@host:/ rw,user_id=1000
+`
+	mounts, err := parseMountInfo(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		mountpoint string
+		device     string
+		fstype     string
+		major      uint32
+		minor      uint32
+		remote     bool
+	}{
+		{"/", "/dev/vda1", "ext4", 252, 1, false},
+		{"/dev", "udev", "devtmpfs", 0, 5, false},
+		{"/mnt/my photos", "server:/export dir", "nfs4", 0, 50, true},
+		{"/home/user/remote", "user@host:/", "fuse.sshfs", 0, 51, true},
+	}
+
+	if len(mounts) != len(want) {
+		t.Fatalf("got %d mounts, want %d", len(mounts), len(want))
+	}
+	for i, w := range want {
+		fs := mounts[i].fs
+		if fs.Mountpoint != w.mountpoint || fs.Device != w.device || fs.FSType != w.fstype {
+			t.Errorf("mount %d = %q %q %q, want %q %q %q", i, fs.Mountpoint, fs.Device, fs.FSType, w.mountpoint, w.device, w.fstype)
+		}
+		if fs.Major != w.major || fs.Minor != w.minor {
+			t.Errorf("%s device = %d:%d, want %d:%d", w.mountpoint, fs.Major, fs.Minor, w.major, w.minor)
+		}
+		if fs.Remote != w.remote {
+			t.Errorf("%s Remote = %v, want %v", w.mountpoint, fs.Remote, w.remote)
+		}
+	}
+}
+
+func TestSelectMounts(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name: "bind mount of a subdirectory",
+			input: `22 1 252:1 / / rw - ext4 /dev/vda1 rw
+30 22 252:1 /var/lib/docker /srv/docker rw - ext4 /dev/vda1 rw
+`,
+			want: []string{"/dev/vda1 on /"},
+		},
+		{
+			// The root mount is preferred even when the bind mount comes first
+			name: "bind mount before the root mount",
+			input: `31 22 252:2 /data /mnt/bind rw - xfs /dev/vdb1 rw
+32 22 252:2 / /mnt/data rw - xfs /dev/vdb1 rw
+`,
+			want: []string{"/dev/vdb1 on /mnt/data"},
+		},
+		{
+			name:  "only a bind mount",
+			input: "31 22 252:2 /data /mnt/bind rw - xfs /dev/vdb1 rw\n",
+			want:  []string{"/dev/vdb1 on /mnt/bind"},
+		},
+		{
+			name: "shadowed mount",
+			input: `33 22 252:3 / /mnt/old rw - ext4 /dev/vdc1 rw
+34 22 252:4 / /mnt/old rw - ext4 /dev/vdd1 rw
+`,
+			want: []string{"/dev/vdd1 on /mnt/old"},
+		},
+		{
+			// The root mount of 252:2 is hidden, so its bind mount stands in for it
+			name: "root mount shadowed",
+			input: `40 22 252:2 / /mnt/a rw - xfs /dev/vdb1 rw
+41 22 252:2 /sub /mnt/b rw - xfs /dev/vdb1 rw
+42 22 252:3 / /mnt/a rw - ext4 /dev/vdc1 rw
+`,
+			want: []string{"/dev/vdb1 on /mnt/b", "/dev/vdc1 on /mnt/a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mounts, err := parseMountInfo(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, m := range selectMounts(mounts) {
+				got = append(got, m.fs.Device+" on "+m.fs.Mountpoint)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("selected %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
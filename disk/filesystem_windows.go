@@ -0,0 +1,46 @@
+//go:build windows
+// +build windows
+
+package disk
+
+// Filesystem represents the capacity and inode usage of one mounted filesystem
+type Filesystem struct {
+	Device     string   `json:"device"`
+	Mountpoint string   `json:"mountpoint"`
+	FSType     string   `json:"fstype"`
+	Options    []string `json:"options"`
+	Major      uint32   `json:"major"`
+	Minor      uint32   `json:"minor"`
+	Remote     bool     `json:"remote"`
+
+	Total       uint64  `json:"total"`
+	Used        uint64  `json:"used"`
+	Free        uint64  `json:"free"`
+	Available   uint64  `json:"available"`
+	UsedPercent float64 `json:"used_percent"`
+
+	Inodes            uint64  `json:"inodes"`
+	InodesUsed        uint64  `json:"inodes_used"`
+	InodesFree        uint64  `json:"inodes_free"`
+	InodesUsedPercent float64 `json:"inodes_used_percent"`
+}
+
+// GetFilesystems returns a mock filesystem for Windows
+func GetFilesystems(filters ...FilesystemFilter) ([]Filesystem, error) {
+	var filesystems []Filesystem
+	fs := Filesystem{
+		Device:      "C:",
+		Mountpoint:  `C:\`,
+		FSType:      "NTFS",
+		Options:     []string{"rw"},
+		Total:       256 * 1024 * 1024 * 1024,
+		Used:        128 * 1024 * 1024 * 1024,
+		Free:        128 * 1024 * 1024 * 1024,
+		Available:   128 * 1024 * 1024 * 1024,
+		UsedPercent: 50,
+	}
+	if matchesFilesystem(&fs, filters) {
+		filesystems = append(filesystems, fs)
+	}
+	return filesystems, nil
+}
@@ -0,0 +1,99 @@
+package disk
+
+// DeviceType classifies a block device
+type DeviceType string
+
+const (
+	TypeDisk      DeviceType = "disk"
+	TypePartition DeviceType = "partition"
+	TypeDM        DeviceType = "dm"
+	TypeMD        DeviceType = "md"
+	TypeLoop      DeviceType = "loop"
+	TypeZram      DeviceType = "zram"
+	TypeNBD       DeviceType = "nbd"
+	TypeRAM       DeviceType = "ram"
+	TypeOptical   DeviceType = "optical"
+)
+
+// Filter decides whether GetDisk returns a device. Filters see the name,
+// device numbers, type and counters; Parent and Rotational are read from
+// sysfs afterwards, only for the devices that are kept.
+type Filter func(d *DiskStats) bool
+
+// DefaultFilter is applied when GetDisk is called without filters. It drops
+// loop, RAM and optical devices and keeps everything else, partitions included.
+var DefaultFilter = ExcludeTypes(TypeLoop, TypeRAM, TypeOptical)
+
+// AllDevices keeps every device in /proc/diskstats
+func AllDevices(d *DiskStats) bool {
+	return true
+}
+
+// WholeDisks keeps only physical disks, so counters can be summed without
+// double counting partitions or the virtual devices stacked on them
+func WholeDisks(d *DiskStats) bool {
+	return d.Type == TypeDisk
+}
+
+// IncludeTypes keeps only devices of the given types
+func IncludeTypes(types ...DeviceType) Filter {
+	return func(d *DiskStats) bool {
+		for _, t := range types {
+			if d.Type == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ExcludeTypes drops devices of the given types
+func ExcludeTypes(types ...DeviceType) Filter {
+	include := IncludeTypes(types...)
+	return func(d *DiskStats) bool {
+		return !include(d)
+	}
+}
+
+// matches reports whether d passes every filter, or DefaultFilter when none are given
+func matches(d *DiskStats, filters []Filter) bool {
+	if len(filters) == 0 {
+		return DefaultFilter(d)
+	}
+	for _, f := range filters {
+		if !f(d) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilesystemFilter decides whether GetFilesystems reports a mount. Filters run
+// before statfs(2), so they see the mount fields but not the usage.
+type FilesystemFilter func(fs *Filesystem) bool
+
+// LocalFilesystems is applied when GetFilesystems is called without filters.
+// It skips network and FUSE filesystems, whose statfs(2) can block on an
+// unreachable server.
+func LocalFilesystems(fs *Filesystem) bool {
+	return !fs.Remote
+}
+
+// AllFilesystems keeps every mount, remote ones included. statfs(2) on a remote
+// filesystem is given a deadline, and a mount that misses it is skipped.
+func AllFilesystems(fs *Filesystem) bool {
+	return true
+}
+
+// matchesFilesystem reports whether fs passes every filter, or LocalFilesystems when none are given
+func matchesFilesystem(fs *Filesystem, filters []FilesystemFilter) bool {
+	if len(filters) == 0 {
+		return LocalFilesystems(fs)
+	}
+	for _, f := range filters {
+		if !f(fs) {
+			return false
+		}
+	}
+	return true
+}
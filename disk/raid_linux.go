@@ -0,0 +1,257 @@
+//go:build linux
+// +build linux
+
+package disk
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RAIDMember represents one component device of an md array
+type RAIDMember struct {
+	Name string `json:"name"`
+	// Slot is the role number shown in brackets, e.g. 1 for sdb1[1]
+	Slot   int  `json:"slot"`
+	Failed bool `json:"failed"`
+	Spare  bool `json:"spare"`
+}
+
+// RAIDSync represents a resync, recovery, reshape or check in progress
+type RAIDSync struct {
+	// Action is "resync", "recovery", "reshape", "check" or "repair"
+	Action string `json:"action"`
+	// Pending is true for "resync=DELAYED" or "resync=PENDING", with no progress yet
+	Pending bool    `json:"pending"`
+	Percent float64 `json:"percent"`
+	// Done and Total are in 1K blocks
+	Done      uint64        `json:"done"`
+	Total     uint64        `json:"total"`
+	ETA       time.Duration `json:"eta"`
+	SpeedKBps uint64        `json:"speed_kbps"`
+}
+
+// RAIDArray represents a software RAID array from /proc/mdstat
+type RAIDArray struct {
+	Name string `json:"name"`
+	// State is "active" or "inactive"; ReadOnly is set for (read-only) and (auto-read-only)
+	State    string       `json:"state"`
+	ReadOnly bool         `json:"read_only"`
+	Level    string       `json:"level"`
+	Members  []RAIDMember `json:"members"`
+	// Blocks is the array size in 1K blocks
+	Blocks uint64 `json:"blocks"`
+	// TotalDevices and ActiveDevices come from "[n/m]"; both are zero for
+	// levels without redundancy (raid0, linear)
+	TotalDevices  int `json:"total_devices"`
+	ActiveDevices int `json:"active_devices"`
+	// Status is the per-slot map, e.g. "UU_" where "_" marks a missing device
+	Status   string    `json:"status"`
+	Degraded bool      `json:"degraded"`
+	Sync     *RAIDSync `json:"sync,omitempty"`
+}
+
+// FailedMembers returns the members marked faulty
+func (a *RAIDArray) FailedMembers() []RAIDMember {
+	var failed []RAIDMember
+	for _, m := range a.Members {
+		if m.Failed {
+			failed = append(failed, m)
+		}
+	}
+	return failed
+}
+
+// GetRAIDArrays returns every md array from /proc/mdstat. It returns an empty
+// slice when the md driver is not loaded.
+func GetRAIDArrays() ([]RAIDArray, error) {
+	file, err := os.Open("/proc/mdstat")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []RAIDArray{}, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseMDStat(file)
+}
+
+func parseMDStat(r io.Reader) ([]RAIDArray, error) {
+	arrays := []RAIDArray{}
+	var cur *RAIDArray
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// md1 : active raid5 sdd1[4] sdc1[2] sdb2[1](F) sda2[0]
+		if len(fields) >= 3 && fields[1] == ":" && strings.HasPrefix(fields[0], "md") {
+			arrays = append(arrays, parseMDHeader(fields[0], fields[2:]))
+			cur = &arrays[len(arrays)-1]
+			continue
+		}
+		if cur == nil || !strings.HasPrefix(line, " ") {
+			// Personalities, unused devices, or the end of an array block
+			cur = nil
+			continue
+		}
+
+		switch {
+		case len(fields) > 1 && fields[1] == "blocks":
+			// 3142656 blocks super 1.2 level 5, 512k chunk, algorithm 2 [3/2] [UU_]
+			cur.Blocks, _ = strconv.ParseUint(fields[0], 10, 64)
+			for _, f := range fields {
+				if !strings.HasPrefix(f, "[") || !strings.HasSuffix(f, "]") {
+					continue
+				}
+				inner := strings.Trim(f, "[]")
+				if total, active, ok := strings.Cut(inner, "/"); ok {
+					cur.TotalDevices, _ = strconv.Atoi(total)
+					cur.ActiveDevices, _ = strconv.Atoi(active)
+				} else if strings.Trim(inner, "U_") == "" {
+					cur.Status = inner
+				}
+			}
+		case strings.Contains(fields[0], "=") && !strings.HasPrefix(fields[0], "["):
+			// resync=DELAYED or resync=PENDING
+			action, _, _ := strings.Cut(fields[0], "=")
+			cur.Sync = &RAIDSync{Action: action, Pending: true}
+		case strings.HasPrefix(fields[0], "[") && strings.Contains(line, "%"):
+			cur.Sync = parseMDSync(fields)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range arrays {
+		a := &arrays[i]
+		a.Degraded = a.ActiveDevices < a.TotalDevices || len(a.FailedMembers()) > 0
+	}
+
+	return arrays, nil
+}
+
+func parseMDHeader(name string, fields []string) RAIDArray {
+	array := RAIDArray{Name: name, State: fields[0]}
+
+	for _, f := range fields[1:] {
+		switch {
+		case strings.HasPrefix(f, "("):
+			// (read-only), (auto-read-only)
+			array.ReadOnly = array.ReadOnly || strings.Contains(f, "read-only")
+		case !strings.Contains(f, "[") && array.Level == "" && array.Members == nil:
+			array.Level = f
+		default:
+			if m, ok := parseMDMember(f); ok {
+				array.Members = append(array.Members, m)
+			}
+		}
+	}
+
+	return array
+}
+
+// parseMDMember parses a component such as "sdb2[1](F)"
+func parseMDMember(s string) (RAIDMember, bool) {
+	lb := strings.IndexByte(s, '[')
+	rb := strings.IndexByte(s, ']')
+	if lb <= 0 || rb < lb {
+		return RAIDMember{}, false
+	}
+
+	m := RAIDMember{Name: s[:lb]}
+	m.Slot, _ = strconv.Atoi(s[lb+1 : rb])
+	flags := s[rb+1:]
+	m.Failed = strings.Contains(flags, "(F)")
+	m.Spare = strings.Contains(flags, "(S)")
+	return m, true
+}
+
+// parseMDSync parses a progress line such as
+// "[=>.....]  recovery =  8.5% (134016/1570816) finish=0.5min speed=44672K/sec"
+func parseMDSync(fields []string) *RAIDSync {
+	sync := &RAIDSync{}
+	for i, f := range fields {
+		switch {
+		case f == "=" && i > 0:
+			sync.Action = fields[i-1]
+		case strings.HasSuffix(f, "%"):
+			sync.Percent, _ = strconv.ParseFloat(strings.TrimSuffix(f, "%"), 64)
+		case strings.HasPrefix(f, "(") && strings.Contains(f, "/"):
+			done, total, _ := strings.Cut(strings.Trim(f, "()"), "/")
+			sync.Done, _ = strconv.ParseUint(done, 10, 64)
+			sync.Total, _ = strconv.ParseUint(total, 10, 64)
+		case strings.HasPrefix(f, "finish="):
+			if minutes, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimPrefix(f, "finish="), "min"), 64); err == nil {
+				sync.ETA = time.Duration(minutes * float64(time.Minute))
+			}
+		case strings.HasPrefix(f, "speed="):
+			sync.SpeedKBps, _ = strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(f, "speed="), "K/sec"), 10, 64)
+		}
+	}
+	return sync
+}
+
+// DMDevice represents a device-mapper device resolved to its mapper name
+type DMDevice struct {
+	// Name is the kernel name, e.g. "dm-3"; MapperName is the /dev/mapper name, e.g. "vg0-root"
+	Name       string `json:"name"`
+	MapperName string `json:"mapper_name"`
+	UUID       string `json:"uuid"`
+	// Target is derived from the UUID prefix: "lvm", "crypt", "mpath", "part" or "" if unknown
+	Target string `json:"target"`
+	// Slaves are the devices this one is stacked on
+	Slaves    []string `json:"slaves"`
+	Suspended bool     `json:"suspended"`
+}
+
+// GetDMDevices returns every device-mapper device with its mapper name,
+// target type and underlying devices from /sys/block/dm-*
+func GetDMDevices() ([]DMDevice, error) {
+	dirs, err := filepath.Glob(filepath.Join(sysBlockPath, "dm-*"))
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []DMDevice{}
+	for _, dir := range dirs {
+		dev := DMDevice{
+			Name:       filepath.Base(dir),
+			MapperName: readSysString(filepath.Join(dir, "dm/name")),
+			UUID:       readSysString(filepath.Join(dir, "dm/uuid")),
+			Suspended:  readSysString(filepath.Join(dir, "dm/suspended")) == "1",
+			Slaves:     []string{},
+		}
+
+		// UUIDs are prefixed by the tool that created the mapping: LVM-, CRYPT-, mpath-, part1-
+		prefix, _, _ := strings.Cut(strings.ToLower(dev.UUID), "-")
+		switch {
+		case prefix == "lvm", prefix == "crypt", prefix == "mpath":
+			dev.Target = prefix
+		case strings.HasPrefix(prefix, "part"):
+			dev.Target = "part"
+		}
+
+		if slaves, err := os.ReadDir(filepath.Join(dir, "slaves")); err == nil {
+			for _, s := range slaves {
+				dev.Slaves = append(dev.Slaves, s.Name())
+			}
+		}
+
+		devices = append(devices, dev)
+	}
+
+	return devices, nil
+}
@@ -0,0 +1,171 @@
+//go:build linux
+// +build linux
+
+package disk
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const mdstatFixture = `Personalities : [raid1] [raid6] [raid5] [raid4] [raid0]
+md2 : active raid1 sdb1[1] sda1[0]
+      1048512 blocks super 1.2 [2/2] [UU]
+      [=>...................]  resync =  8.5% (89216/1048512) finish=0.5min speed=29738K/sec
+      bitmap: 1/1 pages [4KB], 65536KB chunk
+
+md1 : active raid5 sdd1[3] sdc1[2] sdb2[1](F) sda2[0]
+      3142656 blocks super 1.2 level 5, 512k chunk, algorithm 2 [4/3] [U_UU]
+      [==>..................]  recovery = 12.5% (131072/1048576) finish=2.0min speed=7281K/sec
+
+md3 : active (auto-read-only) raid1 sde1[1] sdf1[2](S)
+      524224 blocks super 1.2 [2/1] [_U]
+        resync=PENDING
+
+md0 : active raid0 sdg[1] sdh[0]
+      2095104 blocks super 1.2 512k chunks
+
+md127 : inactive sdi[0](S)
+      1048552 blocks super 1.2
+
+unused devices: <none>
+`
+
+func TestParseMDStat(t *testing.T) {
+	arrays, err := parseMDStat(strings.NewReader(mdstatFixture))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []RAIDArray{
+		{
+			Name:  "md2",
+			State: "active",
+			Level: "raid1",
+			Members: []RAIDMember{
+				{Name: "sdb1", Slot: 1},
+				{Name: "sda1", Slot: 0},
+			},
+			Blocks:        1048512,
+			TotalDevices:  2,
+			ActiveDevices: 2,
+			Status:        "UU",
+			Sync: &RAIDSync{
+				Action:    "resync",
+				Percent:   8.5,
+				Done:      89216,
+				Total:     1048512,
+				ETA:       30 * time.Second,
+				SpeedKBps: 29738,
+			},
+		},
+		{
+			Name:  "md1",
+			State: "active",
+			Level: "raid5",
+			Members: []RAIDMember{
+				{Name: "sdd1", Slot: 3},
+				{Name: "sdc1", Slot: 2},
+				{Name: "sdb2", Slot: 1, Failed: true},
+				{Name: "sda2", Slot: 0},
+			},
+			Blocks:        3142656,
+			TotalDevices:  4,
+			ActiveDevices: 3,
+			Status:        "U_UU",
+			Degraded:      true,
+			Sync: &RAIDSync{
+				Action:    "recovery",
+				Percent:   12.5,
+				Done:      131072,
+				Total:     1048576,
+				ETA:       2 * time.Minute,
+				SpeedKBps: 7281,
+			},
+		},
+		{
+			Name:     "md3",
+			State:    "active",
+			ReadOnly: true,
+			Level:    "raid1",
+			Members: []RAIDMember{
+				{Name: "sde1", Slot: 1},
+				{Name: "sdf1", Slot: 2, Spare: true},
+			},
+			Blocks:        524224,
+			TotalDevices:  2,
+			ActiveDevices: 1,
+			Status:        "_U",
+			Degraded:      true,
+			Sync:          &RAIDSync{Action: "resync", Pending: true},
+		},
+		{
+			// raid0 has no redundancy, so there is no [n/m] or status map
+			Name:  "md0",
+			State: "active",
+			Level: "raid0",
+			Members: []RAIDMember{
+				{Name: "sdg", Slot: 1},
+				{Name: "sdh", Slot: 0},
+			},
+			Blocks: 2095104,
+		},
+		{
+			Name:  "md127",
+			State: "inactive",
+			Members: []RAIDMember{
+				{Name: "sdi", Slot: 0, Spare: true},
+			},
+			Blocks: 1048552,
+		},
+	}
+
+	if len(arrays) != len(tests) {
+		t.Fatalf("got %d arrays, want %d", len(arrays), len(tests))
+	}
+	for i, want := range tests {
+		t.Run(want.Name, func(t *testing.T) {
+			got := arrays[i]
+			if got.Sync != nil && want.Sync != nil && !reflect.DeepEqual(*got.Sync, *want.Sync) {
+				t.Errorf("Sync = %+v, want %+v", *got.Sync, *want.Sync)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got  %+v\nwant %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseMDStatEmpty(t *testing.T) {
+	input := "Personalities : \nunused devices: <none>\n"
+	arrays, err := parseMDStat(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if arrays == nil || len(arrays) != 0 {
+		t.Errorf("got %v, want an empty non-nil slice", arrays)
+	}
+}
+
+func TestParseMDMember(t *testing.T) {
+	tests := []struct {
+		input string
+		want  RAIDMember
+		ok    bool
+	}{
+		{input: "sda1[0]", want: RAIDMember{Name: "sda1", Slot: 0}, ok: true},
+		{input: "sdb2[1](F)", want: RAIDMember{Name: "sdb2", Slot: 1, Failed: true}, ok: true},
+		{input: "nvme0n1p3[2](S)", want: RAIDMember{Name: "nvme0n1p3", Slot: 2, Spare: true}, ok: true},
+		{input: "raid1", ok: false},
+		{input: "[1]", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseMDMember(tt.input)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseMDMember(%q) = %+v, %v, want %+v, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+//go:build windows
+// +build windows
+
+package disk
+
+import "time"
+
+// RAIDMember represents one component device of an md array
+type RAIDMember struct {
+	Name   string `json:"name"`
+	Slot   int    `json:"slot"`
+	Failed bool   `json:"failed"`
+	Spare  bool   `json:"spare"`
+}
+
+// RAIDSync represents a resync, recovery, reshape or check in progress
+type RAIDSync struct {
+	Action    string        `json:"action"`
+	Pending   bool          `json:"pending"`
+	Percent   float64       `json:"percent"`
+	Done      uint64        `json:"done"`
+	Total     uint64        `json:"total"`
+	ETA       time.Duration `json:"eta"`
+	SpeedKBps uint64        `json:"speed_kbps"`
+}
+
+// RAIDArray represents a software RAID array
+type RAIDArray struct {
+	Name          string       `json:"name"`
+	State         string       `json:"state"`
+	ReadOnly      bool         `json:"read_only"`
+	Level         string       `json:"level"`
+	Members       []RAIDMember `json:"members"`
+	Blocks        uint64       `json:"blocks"`
+	TotalDevices  int          `json:"total_devices"`
+	ActiveDevices int          `json:"active_devices"`
+	Status        string       `json:"status"`
+	Degraded      bool         `json:"degraded"`
+	Sync          *RAIDSync    `json:"sync,omitempty"`
+}
+
+// FailedMembers returns the members marked faulty
+func (a *RAIDArray) FailedMembers() []RAIDMember {
+	var failed []RAIDMember
+	for _, m := range a.Members {
+		if m.Failed {
+			failed = append(failed, m)
+		}
+	}
+	return failed
+}
+
+// DMDevice represents a device-mapper device resolved to its mapper name
+type DMDevice struct {
+	Name       string   `json:"name"`
+	MapperName string   `json:"mapper_name"`
+	UUID       string   `json:"uuid"`
+	Target     string   `json:"target"`
+	Slaves     []string `json:"slaves"`
+	Suspended  bool     `json:"suspended"`
+}
+
+// GetRAIDArrays returns no arrays on Windows
+func GetRAIDArrays() ([]RAIDArray, error) {
+	return []RAIDArray{}, nil
+}
+
+// GetDMDevices returns no device-mapper devices on Windows
+func GetDMDevices() ([]DMDevice, error) {
+	return []DMDevice{}, nil
+}
@@ -0,0 +1,46 @@
+package disk
+
+import (
+	"time"
+
+	"github.com/avirooppal/gosysutil/sampler"
+)
+
+// Sampler reads disk statistics in the background on a fixed interval and
+// serves usage computed from the last two samples, so callers never block
+// waiting for a measurement window.
+type Sampler struct {
+	s *sampler.Sampler[[]DiskStats, []Usage]
+}
+
+// NewSampler returns a Sampler that reads /proc/diskstats every interval,
+// keeping the devices selected by filters as GetDisk does.
+// Call Start to begin sampling.
+func NewSampler(interval time.Duration, filters ...Filter) *Sampler {
+	read := func() ([]DiskStats, error) {
+		return GetDisk(filters...)
+	}
+	return &Sampler{s: sampler.New(interval, read, CalculateUsage)}
+}
+
+// Start takes an initial sample and begins sampling in the background.
+// Usage is available once the first interval has elapsed.
+// Calling Start more than once has no effect.
+func (s *Sampler) Start() error {
+	return s.s.Start()
+}
+
+// Stop ends background sampling. The last computed usage remains available.
+func (s *Sampler) Stop() {
+	s.s.Stop()
+}
+
+// Usage returns the usage of every disk over the last interval, or
+// sampler.ErrNotReady until the second sample has been taken
+func (s *Sampler) Usage() ([]Usage, error) {
+	usage, err := s.s.Value()
+	if err != nil {
+		return nil, err
+	}
+	return append([]Usage{}, usage...), nil
+}
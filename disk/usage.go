@@ -0,0 +1,113 @@
+package disk
+
+// sectorSize is the unit of the sector counters in /proc/diskstats, which the
+// kernel always reports in 512-byte sectors regardless of the device's block size
+const sectorSize = 512
+
+// Usage represents the I/O rates, utilization and latency of one disk between
+// two samples, matching the columns of iostat -x
+type Usage struct {
+	Name string `json:"name"`
+	// Completed requests per second (r/s, w/s) and merged requests per second (rrqm/s, wrqm/s)
+	ReadsPerSec        float64 `json:"reads_per_sec"`
+	WritesPerSec       float64 `json:"writes_per_sec"`
+	ReadsMergedPerSec  float64 `json:"reads_merged_per_sec"`
+	WritesMergedPerSec float64 `json:"writes_merged_per_sec"`
+	ReadBytesPerSec    float64 `json:"read_bytes_per_sec"`
+	WriteBytesPerSec   float64 `json:"write_bytes_per_sec"`
+	// ReadAwait and WriteAwait are the average milliseconds per completed request,
+	// including time spent queued (r_await, w_await)
+	ReadAwait  float64 `json:"read_await_ms"`
+	WriteAwait float64 `json:"write_await_ms"`
+	// AvgQueueSize is the average number of requests in flight (aqu-sz)
+	AvgQueueSize float64 `json:"avg_queue_size"`
+	// UtilPercent is the share of time the device had I/O in progress (%util)
+	UtilPercent float64 `json:"util_percent"`
+	// Discard and flush rates (d/s, dkB/s, d_await, f/s, f_await); zero when
+	// the kernel does not provide those counters
+	DiscardsPerSec     float64 `json:"discards_per_sec"`
+	DiscardBytesPerSec float64 `json:"discard_bytes_per_sec"`
+	DiscardAwait       float64 `json:"discard_await_ms"`
+	FlushesPerSec      float64 `json:"flushes_per_sec"`
+	FlushAwait         float64 `json:"flush_await_ms"`
+}
+
+// CalculateUsage computes the usage of every disk present in both samples,
+// using the time elapsed between their timestamps
+func CalculateUsage(prev, cur []DiskStats) []Usage {
+	last := make(map[string]*DiskStats, len(prev))
+	for i := range prev {
+		last[prev[i].Name] = &prev[i]
+	}
+
+	var usage []Usage
+	for i := range cur {
+		p, ok := last[cur[i].Name]
+		if !ok {
+			continue
+		}
+		if u := calculateUsage(p, &cur[i]); u != nil {
+			usage = append(usage, *u)
+		}
+	}
+	return usage
+}
+
+// calculateUsage returns nil when the samples are not in chronological order
+func calculateUsage(prev, cur *DiskStats) *Usage {
+	seconds := cur.Timestamp.Sub(prev.Timestamp).Seconds()
+	if seconds <= 0 {
+		return nil
+	}
+	millis := seconds * 1000
+
+	reads := delta(prev.ReadsCompleted, cur.ReadsCompleted)
+	writes := delta(prev.WritesCompleted, cur.WritesCompleted)
+
+	u := &Usage{
+		Name:               cur.Name,
+		ReadsPerSec:        reads / seconds,
+		WritesPerSec:       writes / seconds,
+		ReadsMergedPerSec:  delta(prev.ReadsMerged, cur.ReadsMerged) / seconds,
+		WritesMergedPerSec: delta(prev.WritesMerged, cur.WritesMerged) / seconds,
+		ReadBytesPerSec:    delta(prev.SectorsRead, cur.SectorsRead) * sectorSize / seconds,
+		WriteBytesPerSec:   delta(prev.SectorsWritten, cur.SectorsWritten) * sectorSize / seconds,
+		AvgQueueSize:       delta(prev.WeightedIoTime, cur.WeightedIoTime) / millis,
+		UtilPercent:        delta(prev.IoTime, cur.IoTime) / millis * 100,
+	}
+	if reads > 0 {
+		u.ReadAwait = delta(prev.ReadTime, cur.ReadTime) / reads
+	}
+	if writes > 0 {
+		u.WriteAwait = delta(prev.WriteTime, cur.WriteTime) / writes
+	}
+	if cur.HasDiscard() {
+		discards := delta(prev.DiscardsCompleted, cur.DiscardsCompleted)
+		u.DiscardsPerSec = discards / seconds
+		u.DiscardBytesPerSec = delta(prev.SectorsDiscarded, cur.SectorsDiscarded) * sectorSize / seconds
+		if discards > 0 {
+			u.DiscardAwait = delta(prev.DiscardTime, cur.DiscardTime) / discards
+		}
+	}
+	if cur.HasFlush() {
+		flushes := delta(prev.FlushesCompleted, cur.FlushesCompleted)
+		u.FlushesPerSec = flushes / seconds
+		if flushes > 0 {
+			u.FlushAwait = delta(prev.FlushTime, cur.FlushTime) / flushes
+		}
+	}
+	// IoTime advances in jiffies, so a fully busy device can overshoot slightly
+	if u.UtilPercent > 100 {
+		u.UtilPercent = 100
+	}
+
+	return u
+}
+
+// delta returns cur - prev, or 0 if the counter went backwards (device reset or wrap)
+func delta(prev, cur uint64) float64 {
+	if cur < prev {
+		return 0
+	}
+	return float64(cur - prev)
+}
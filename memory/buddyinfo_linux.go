@@ -0,0 +1,185 @@
+//go:build linux
+// +build linux
+
+package memory
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BuddyZone represents the free block counts of one memory zone from /proc/buddyinfo
+type BuddyZone struct {
+	Node int    `json:"node"`
+	Zone string `json:"zone"`
+	// FreeBlocks[order] is the number of free blocks of 2^order pages
+	FreeBlocks []uint64 `json:"free_blocks"`
+	FreePages  uint64   `json:"free_pages"`
+	// FragmentationIndex[order] is the unusable free space index: the fraction
+	// (0 to 1) of free memory that cannot satisfy an allocation of that order
+	FragmentationIndex []float64 `json:"fragmentation_index"`
+}
+
+// PageTypeFree represents free blocks per order for one migrate type of a zone
+type PageTypeFree struct {
+	Node       int      `json:"node"`
+	Zone       string   `json:"zone"`
+	Type       string   `json:"type"`
+	FreeBlocks []uint64 `json:"free_blocks"`
+}
+
+// PageTypeBlocks represents the number of page blocks of each migrate type in a zone
+type PageTypeBlocks struct {
+	Node   int               `json:"node"`
+	Zone   string            `json:"zone"`
+	Blocks map[string]uint64 `json:"blocks"`
+}
+
+// PageTypeInfo represents the per-migratetype detail from /proc/pagetypeinfo
+type PageTypeInfo struct {
+	PageBlockOrder int              `json:"page_block_order"`
+	PagesPerBlock  int              `json:"pages_per_block"`
+	Free           []PageTypeFree   `json:"free"`
+	Blocks         []PageTypeBlocks `json:"blocks"`
+}
+
+// GetBuddyInfo returns free blocks per order for every zone of every node,
+// with the fragmentation index of each order
+func GetBuddyInfo() ([]BuddyZone, error) {
+	file, err := os.Open("/proc/buddyinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseBuddyInfo(file)
+}
+
+// parseBuddyInfo parses /proc/buddyinfo content
+func parseBuddyInfo(r io.Reader) ([]BuddyZone, error) {
+	var zones []BuddyZone
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// Node 0, zone   Normal   4288   1452    216 ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "Node" || fields[2] != "zone" {
+			continue
+		}
+
+		node, err := strconv.Atoi(strings.TrimSuffix(fields[1], ","))
+		if err != nil {
+			return nil, fmt.Errorf("invalid node in /proc/buddyinfo: %q", fields[1])
+		}
+
+		zone := BuddyZone{Node: node, Zone: fields[3]}
+		zone.FreeBlocks = parseOrders(fields[4:])
+		zone.FreePages, zone.FragmentationIndex = fragmentation(zone.FreeBlocks)
+		zones = append(zones, zone)
+	}
+
+	return zones, scanner.Err()
+}
+
+// GetPageTypeInfo returns free blocks per order and migrate type, and page block
+// counts per migrate type, from /proc/pagetypeinfo (root-only on recent kernels)
+func GetPageTypeInfo() (*PageTypeInfo, error) {
+	file, err := os.Open("/proc/pagetypeinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parsePageTypeInfo(file)
+}
+
+// parsePageTypeInfo parses /proc/pagetypeinfo content
+func parsePageTypeInfo(r io.Reader) (*PageTypeInfo, error) {
+	info := &PageTypeInfo{}
+	var blockTypes []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "Page block order:"):
+			info.PageBlockOrder, _ = strconv.Atoi(fields[len(fields)-1])
+		case strings.HasPrefix(line, "Pages per block:"):
+			info.PagesPerBlock, _ = strconv.Atoi(fields[len(fields)-1])
+		case strings.HasPrefix(line, "Number of blocks type"):
+			blockTypes = fields[4:]
+		case fields[0] == "Node" && len(fields) > 6 && fields[4] == "type":
+			// Node    0, zone   Normal, type    Movable   4287   1381 ...
+			node, _ := strconv.Atoi(strings.TrimSuffix(fields[1], ","))
+			info.Free = append(info.Free, PageTypeFree{
+				Node:       node,
+				Zone:       strings.TrimSuffix(fields[3], ","),
+				Type:       fields[5],
+				FreeBlocks: parseOrders(fields[6:]),
+			})
+		case fields[0] == "Node" && blockTypes != nil && len(fields) >= 4:
+			// Node 0, zone   Normal           60          747 ...
+			node, _ := strconv.Atoi(strings.TrimSuffix(fields[1], ","))
+			blocks := PageTypeBlocks{
+				Node:   node,
+				Zone:   fields[3],
+				Blocks: make(map[string]uint64),
+			}
+			for i, count := range parseOrders(fields[4:]) {
+				if i < len(blockTypes) {
+					blocks.Blocks[blockTypes[i]] = count
+				}
+			}
+			info.Blocks = append(info.Blocks, blocks)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func parseOrders(fields []string) []uint64 {
+	counts := make([]uint64, 0, len(fields))
+	for _, field := range fields {
+		count, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			break
+		}
+		counts = append(counts, count)
+	}
+	return counts
+}
+
+// fragmentation returns the total free pages of a zone and, for each order, the
+// share of those pages sitting in blocks too small to serve that order
+func fragmentation(freeBlocks []uint64) (uint64, []float64) {
+	var freePages uint64
+	for order, blocks := range freeBlocks {
+		freePages += blocks << uint(order)
+	}
+
+	index := make([]float64, len(freeBlocks))
+	if freePages == 0 {
+		return 0, index
+	}
+
+	// Walk down from the highest order accumulating pages usable at each order
+	var usable uint64
+	for order := len(freeBlocks) - 1; order >= 0; order-- {
+		usable += freeBlocks[order] << uint(order)
+		index[order] = float64(freePages-usable) / float64(freePages)
+	}
+
+	return freePages, index
+}
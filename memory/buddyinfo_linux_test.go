@@ -0,0 +1,152 @@
+//go:build linux
+// +build linux
+
+package memory
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const buddyInfoFixture = `Node 0, zone      DMA      0      0      0      0      0      0      0      0      1      1      3 
+Node 0, zone    DMA32      2      2      2      2      2      2      5      2      2      2    754 
+Node 0, zone   Normal   3965   1082    194    201    176      2      3      2      2      2     12
+Node 1, zone   Normal      4      2      1
+`
+
+func TestParseBuddyInfo(t *testing.T) {
+	zones, err := parseBuddyInfo(strings.NewReader(buddyInfoFixture))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		node       int
+		zone       string
+		freeBlocks []uint64
+		freePages  uint64
+	}{
+		{0, "DMA", []uint64{0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 3}, 3840},
+		{0, "DMA32", []uint64{2, 2, 2, 2, 2, 2, 5, 2, 2, 2, 754}, 774334},
+		{0, "Normal", []uint64{3965, 1082, 194, 201, 176, 2, 3, 2, 2, 2, 12}, 25665},
+		{1, "Normal", []uint64{4, 2, 1}, 12},
+	}
+
+	if len(zones) != len(tests) {
+		t.Fatalf("got %d zones, want %d", len(zones), len(tests))
+	}
+	for i, tt := range tests {
+		z := zones[i]
+		if z.Node != tt.node || z.Zone != tt.zone {
+			t.Errorf("zone %d = node %d %s, want node %d %s", i, z.Node, z.Zone, tt.node, tt.zone)
+		}
+		if !reflect.DeepEqual(z.FreeBlocks, tt.freeBlocks) {
+			t.Errorf("%s FreeBlocks = %v, want %v", tt.zone, z.FreeBlocks, tt.freeBlocks)
+		}
+		if z.FreePages != tt.freePages {
+			t.Errorf("%s FreePages = %d, want %d", tt.zone, z.FreePages, tt.freePages)
+		}
+		if len(z.FragmentationIndex) != len(tt.freeBlocks) {
+			t.Errorf("%s has %d fragmentation indexes, want %d", tt.zone, len(z.FragmentationIndex), len(tt.freeBlocks))
+		}
+	}
+}
+
+func TestFragmentation(t *testing.T) {
+	tests := []struct {
+		name       string
+		freeBlocks []uint64
+		freePages  uint64
+		index      []float64
+	}{
+		{
+			name:       "empty zone",
+			freeBlocks: []uint64{0, 0, 0},
+			freePages:  0,
+			index:      []float64{0, 0, 0},
+		},
+		{
+			// 12 free pages: 4 in order-0, 4 in order-1 and 4 in order-2 blocks
+			name:       "spread over orders",
+			freeBlocks: []uint64{4, 2, 1},
+			freePages:  12,
+			index:      []float64{0, 4.0 / 12, 8.0 / 12},
+		},
+		{
+			name:       "all in single pages",
+			freeBlocks: []uint64{8, 0, 0, 0},
+			freePages:  8,
+			index:      []float64{0, 1, 1, 1},
+		},
+		{
+			name:       "all in the highest order",
+			freeBlocks: []uint64{0, 0, 2},
+			freePages:  8,
+			index:      []float64{0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			freePages, index := fragmentation(tt.freeBlocks)
+			if freePages != tt.freePages {
+				t.Errorf("freePages = %d, want %d", freePages, tt.freePages)
+			}
+			if len(index) != len(tt.index) {
+				t.Fatalf("got %d indexes, want %d", len(index), len(tt.index))
+			}
+			for order := range index {
+				if math.Abs(index[order]-tt.index[order]) > 1e-9 {
+					t.Errorf("index[%d] = %f, want %f", order, index[order], tt.index[order])
+				}
+			}
+		})
+	}
+}
+
+const pageTypeInfoFixture = `Page block order: 9
+Pages per block:  512
+
+Free pages count per migrate type at order       0      1      2      3      4      5      6      7      8      9     10 
+Node    0, zone      DMA, type    Unmovable      0      0      0      1      1      1      1      1      0      0      0 
+Node    0, zone      DMA, type      Movable      0      0      0      0      0      0      0      0      0      1      3 
+Node    0, zone   Normal, type    Unmovable    120     45     10      2      0      0      0      0      0      0      0 
+Node    0, zone   Normal, type      Movable   4287   1381    216     80     12      3      1      0      1      1     20 
+Node    0, zone   Normal, type  Reclaimable     33     17      4      1      0      0      0      0      0      0      0 
+
+Number of blocks type     Unmovable      Movable  Reclaimable   HighAtomic      Isolate 
+Node 0, zone      DMA            1            7            0            0            0 
+Node 0, zone   Normal           60          747           17            0            0 
+`
+
+func TestParsePageTypeInfo(t *testing.T) {
+	info, err := parsePageTypeInfo(strings.NewReader(pageTypeInfoFixture))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.PageBlockOrder != 9 || info.PagesPerBlock != 512 {
+		t.Errorf("page block order %d, pages per block %d, want 9, 512", info.PageBlockOrder, info.PagesPerBlock)
+	}
+
+	wantFree := []PageTypeFree{
+		{Node: 0, Zone: "DMA", Type: "Unmovable", FreeBlocks: []uint64{0, 0, 0, 1, 1, 1, 1, 1, 0, 0, 0}},
+		{Node: 0, Zone: "DMA", Type: "Movable", FreeBlocks: []uint64{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 3}},
+		{Node: 0, Zone: "Normal", Type: "Unmovable", FreeBlocks: []uint64{120, 45, 10, 2, 0, 0, 0, 0, 0, 0, 0}},
+		{Node: 0, Zone: "Normal", Type: "Movable", FreeBlocks: []uint64{4287, 1381, 216, 80, 12, 3, 1, 0, 1, 1, 20}},
+		{Node: 0, Zone: "Normal", Type: "Reclaimable", FreeBlocks: []uint64{33, 17, 4, 1, 0, 0, 0, 0, 0, 0, 0}},
+	}
+	if !reflect.DeepEqual(info.Free, wantFree) {
+		t.Errorf("Free:\ngot  %+v\nwant %+v", info.Free, wantFree)
+	}
+
+	wantBlocks := []PageTypeBlocks{
+		{Node: 0, Zone: "DMA", Blocks: map[string]uint64{"Unmovable": 1, "Movable": 7, "Reclaimable": 0, "HighAtomic": 0, "Isolate": 0}},
+		{Node: 0, Zone: "Normal", Blocks: map[string]uint64{"Unmovable": 60, "Movable": 747, "Reclaimable": 17, "HighAtomic": 0, "Isolate": 0}},
+	}
+	if !reflect.DeepEqual(info.Blocks, wantBlocks) {
+		t.Errorf("Blocks:\ngot  %+v\nwant %+v", info.Blocks, wantBlocks)
+	}
+}
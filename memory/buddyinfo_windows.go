@@ -0,0 +1,50 @@
+//go:build windows
+// +build windows
+
+package memory
+
+import "fmt"
+
+// BuddyZone represents the free block counts of one memory zone
+type BuddyZone struct {
+	Node               int       `json:"node"`
+	Zone               string    `json:"zone"`
+	FreeBlocks         []uint64  `json:"free_blocks"`
+	FreePages          uint64    `json:"free_pages"`
+	FragmentationIndex []float64 `json:"fragmentation_index"`
+}
+
+// PageTypeFree represents free blocks per order for one migrate type of a zone
+type PageTypeFree struct {
+	Node       int      `json:"node"`
+	Zone       string   `json:"zone"`
+	Type       string   `json:"type"`
+	FreeBlocks []uint64 `json:"free_blocks"`
+}
+
+// PageTypeBlocks represents the number of page blocks of each migrate type in a zone
+type PageTypeBlocks struct {
+	Node   int               `json:"node"`
+	Zone   string            `json:"zone"`
+	Blocks map[string]uint64 `json:"blocks"`
+}
+
+// PageTypeInfo represents the per-migratetype page allocator detail
+type PageTypeInfo struct {
+	PageBlockOrder int              `json:"page_block_order"`
+	PagesPerBlock  int              `json:"pages_per_block"`
+	Free           []PageTypeFree   `json:"free"`
+	Blocks         []PageTypeBlocks `json:"blocks"`
+}
+
+// GetBuddyInfo returns a mock zone for Windows
+func GetBuddyInfo() ([]BuddyZone, error) {
+	return []BuddyZone{
+		{Node: 0, Zone: "Normal", FreeBlocks: []uint64{0, 0, 1}, FreePages: 4, FragmentationIndex: []float64{0, 0, 0}},
+	}, nil
+}
+
+// GetPageTypeInfo is not available on Windows
+func GetPageTypeInfo() (*PageTypeInfo, error) {
+	return nil, fmt.Errorf("pagetypeinfo is not available on windows")
+}
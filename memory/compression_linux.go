@@ -0,0 +1,209 @@
+//go:build linux
+// +build linux
+
+package memory
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ZramDevice represents a compressed RAM block device from /sys/block/zramN
+type ZramDevice struct {
+	Name      string `json:"name"`
+	Algorithm string `json:"algorithm"`
+	DiskSize  uint64 `json:"disk_size"`
+	// Fields of mm_stat, in bytes unless noted
+	OrigDataSize   uint64 `json:"orig_data_size"`
+	ComprDataSize  uint64 `json:"compr_data_size"`
+	MemUsedTotal   uint64 `json:"mem_used_total"`
+	MemLimit       uint64 `json:"mem_limit"`
+	MemUsedMax     uint64 `json:"mem_used_max"`
+	SamePages      uint64 `json:"same_pages"`
+	PagesCompacted uint64 `json:"pages_compacted"`
+	HugePages      uint64 `json:"huge_pages"`
+	// CompressionRatio is OrigDataSize / MemUsedTotal, including allocator overhead
+	CompressionRatio float64 `json:"compression_ratio"`
+}
+
+// ZswapStats represents the compressed swap cache
+type ZswapStats struct {
+	Enabled        bool   `json:"enabled"`
+	Compressor     string `json:"compressor"`
+	MaxPoolPercent int    `json:"max_pool_percent"`
+	// PoolSize is the compressed size and StoredSize the original size of the
+	// pages held in zswap, from meminfo (Zswap/Zswapped) or debugfs on older kernels
+	PoolSize   uint64 `json:"pool_size"`
+	StoredSize uint64 `json:"stored_size"`
+	// Debug holds the counters from /sys/kernel/debug/zswap when debugfs is readable
+	Debug            map[string]uint64 `json:"debug,omitempty"`
+	CompressionRatio float64           `json:"compression_ratio"`
+}
+
+// KSMStats represents kernel samepage merging from /sys/kernel/mm/ksm
+type KSMStats struct {
+	Run           int    `json:"run"`
+	PagesShared   uint64 `json:"pages_shared"`
+	PagesSharing  uint64 `json:"pages_sharing"`
+	PagesUnshared uint64 `json:"pages_unshared"`
+	PagesVolatile uint64 `json:"pages_volatile"`
+	FullScans     uint64 `json:"full_scans"`
+	// SavedBytes is the memory freed by merging (pages_sharing pages)
+	SavedBytes uint64 `json:"saved_bytes"`
+	// CompressionRatio is (pages_shared + pages_sharing) / pages_shared:
+	// how many pages of content each resident shared page stands in for
+	CompressionRatio float64 `json:"compression_ratio"`
+}
+
+// CompressionStats groups zram, zswap and KSM. Mechanisms the kernel does not
+// provide are left nil (or empty for Zram).
+type CompressionStats struct {
+	Zram  []ZramDevice `json:"zram"`
+	Zswap *ZswapStats  `json:"zswap"`
+	KSM   *KSMStats    `json:"ksm"`
+}
+
+// GetCompression returns every memory compression mechanism available on the host
+func GetCompression() (*CompressionStats, error) {
+	stats := &CompressionStats{}
+	var err error
+
+	stats.Zram, err = GetZram()
+	if err != nil {
+		return nil, err
+	}
+
+	if zswap, err := GetZswap(); err == nil {
+		stats.Zswap = zswap
+	}
+	if ksm, err := GetKSM(); err == nil {
+		stats.KSM = ksm
+	}
+
+	return stats, nil
+}
+
+// GetZram returns the usage of every zram device from /sys/block/zram*/mm_stat
+func GetZram() ([]ZramDevice, error) {
+	dirs, err := filepath.Glob("/sys/block/zram*")
+	if err != nil {
+		return nil, err
+	}
+
+	devices := []ZramDevice{}
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(dir, "mm_stat"))
+		if err != nil {
+			continue
+		}
+
+		var vals [8]uint64
+		for i, field := range strings.Fields(string(data)) {
+			if i >= len(vals) {
+				break
+			}
+			vals[i], _ = strconv.ParseUint(field, 10, 64)
+		}
+
+		dev := ZramDevice{
+			Name:           filepath.Base(dir),
+			OrigDataSize:   vals[0],
+			ComprDataSize:  vals[1],
+			MemUsedTotal:   vals[2],
+			MemLimit:       vals[3],
+			MemUsedMax:     vals[4],
+			SamePages:      vals[5],
+			PagesCompacted: vals[6],
+			HugePages:      vals[7],
+		}
+		dev.DiskSize, _ = readUint(filepath.Join(dir, "disksize"))
+		dev.Algorithm, _ = readSelectedMode(filepath.Join(dir, "comp_algorithm"))
+		dev.CompressionRatio = ratio(dev.OrigDataSize, dev.MemUsedTotal)
+
+		devices = append(devices, dev)
+	}
+
+	return devices, nil
+}
+
+// GetZswap returns the zswap settings and pool usage
+func GetZswap() (*ZswapStats, error) {
+	const params = "/sys/module/zswap/parameters"
+
+	enabled, err := os.ReadFile(filepath.Join(params, "enabled"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ZswapStats{
+		Enabled:    strings.TrimSpace(string(enabled)) == "Y",
+		Compressor: readTrimmed(filepath.Join(params, "compressor")),
+	}
+	stats.MaxPoolPercent, _ = strconv.Atoi(readTrimmed(filepath.Join(params, "max_pool_percent")))
+
+	info, err := GetMemInfo()
+	if err != nil {
+		return nil, err
+	}
+	_, hasMemInfo := info.Raw["Zswap"]
+	stats.PoolSize = info.Zswap
+	stats.StoredSize = info.Zswapped
+
+	// debugfs is root-only and usually unmounted; it is the only source before Linux 6.5
+	if files, err := os.ReadDir("/sys/kernel/debug/zswap"); err == nil {
+		stats.Debug = make(map[string]uint64)
+		for _, f := range files {
+			if val, err := readUint(filepath.Join("/sys/kernel/debug/zswap", f.Name())); err == nil {
+				stats.Debug[f.Name()] = val
+			}
+		}
+		if !hasMemInfo {
+			stats.PoolSize = stats.Debug["pool_total_size"]
+			stats.StoredSize = stats.Debug["stored_pages"] * uint64(os.Getpagesize())
+		}
+	}
+
+	stats.CompressionRatio = ratio(stats.StoredSize, stats.PoolSize)
+
+	return stats, nil
+}
+
+// GetKSM returns kernel samepage merging statistics from /sys/kernel/mm/ksm
+func GetKSM() (*KSMStats, error) {
+	const dir = "/sys/kernel/mm/ksm"
+
+	run, err := readUint(filepath.Join(dir, "run"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &KSMStats{Run: int(run)}
+	stats.PagesShared, _ = readUint(filepath.Join(dir, "pages_shared"))
+	stats.PagesSharing, _ = readUint(filepath.Join(dir, "pages_sharing"))
+	stats.PagesUnshared, _ = readUint(filepath.Join(dir, "pages_unshared"))
+	stats.PagesVolatile, _ = readUint(filepath.Join(dir, "pages_volatile"))
+	stats.FullScans, _ = readUint(filepath.Join(dir, "full_scans"))
+
+	stats.SavedBytes = stats.PagesSharing * uint64(os.Getpagesize())
+	stats.CompressionRatio = ratio(stats.PagesShared+stats.PagesSharing, stats.PagesShared)
+
+	return stats, nil
+}
+
+// ratio returns original / compressed, or 0 when nothing is stored
+func ratio(original, compressed uint64) float64 {
+	if compressed == 0 {
+		return 0
+	}
+	return float64(original) / float64(compressed)
+}
+
+func readTrimmed(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
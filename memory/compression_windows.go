@@ -0,0 +1,73 @@
+//go:build windows
+// +build windows
+
+package memory
+
+import "fmt"
+
+// ZramDevice represents a compressed RAM block device
+type ZramDevice struct {
+	Name             string  `json:"name"`
+	Algorithm        string  `json:"algorithm"`
+	DiskSize         uint64  `json:"disk_size"`
+	OrigDataSize     uint64  `json:"orig_data_size"`
+	ComprDataSize    uint64  `json:"compr_data_size"`
+	MemUsedTotal     uint64  `json:"mem_used_total"`
+	MemLimit         uint64  `json:"mem_limit"`
+	MemUsedMax       uint64  `json:"mem_used_max"`
+	SamePages        uint64  `json:"same_pages"`
+	PagesCompacted   uint64  `json:"pages_compacted"`
+	HugePages        uint64  `json:"huge_pages"`
+	CompressionRatio float64 `json:"compression_ratio"`
+}
+
+// ZswapStats represents the compressed swap cache
+type ZswapStats struct {
+	Enabled          bool              `json:"enabled"`
+	Compressor       string            `json:"compressor"`
+	MaxPoolPercent   int               `json:"max_pool_percent"`
+	PoolSize         uint64            `json:"pool_size"`
+	StoredSize       uint64            `json:"stored_size"`
+	Debug            map[string]uint64 `json:"debug,omitempty"`
+	CompressionRatio float64           `json:"compression_ratio"`
+}
+
+// KSMStats represents kernel samepage merging
+type KSMStats struct {
+	Run              int     `json:"run"`
+	PagesShared      uint64  `json:"pages_shared"`
+	PagesSharing     uint64  `json:"pages_sharing"`
+	PagesUnshared    uint64  `json:"pages_unshared"`
+	PagesVolatile    uint64  `json:"pages_volatile"`
+	FullScans        uint64  `json:"full_scans"`
+	SavedBytes       uint64  `json:"saved_bytes"`
+	CompressionRatio float64 `json:"compression_ratio"`
+}
+
+// CompressionStats groups zram, zswap and KSM
+type CompressionStats struct {
+	Zram  []ZramDevice `json:"zram"`
+	Zswap *ZswapStats  `json:"zswap"`
+	KSM   *KSMStats    `json:"ksm"`
+}
+
+// GetCompression returns mock compression statistics for Windows, where
+// zram, zswap and KSM do not exist
+func GetCompression() (*CompressionStats, error) {
+	return &CompressionStats{Zram: []ZramDevice{}}, nil
+}
+
+// GetZram returns no zram devices on Windows
+func GetZram() ([]ZramDevice, error) {
+	return []ZramDevice{}, nil
+}
+
+// GetZswap is not available on Windows
+func GetZswap() (*ZswapStats, error) {
+	return nil, fmt.Errorf("zswap is not available on windows")
+}
+
+// GetKSM is not available on Windows
+func GetKSM() (*KSMStats, error) {
+	return nil, fmt.Errorf("ksm is not available on windows")
+}
@@ -0,0 +1,122 @@
+//go:build linux
+// +build linux
+
+package memory
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HugePagePool represents the reservation of one huge page size from
+// /sys/kernel/mm/hugepages/hugepages-<size>kB. Counts are in pages.
+type HugePagePool struct {
+	SizeBytes  uint64 `json:"size_bytes"`
+	Total      uint64 `json:"total"`
+	Free       uint64 `json:"free"`
+	Reserved   uint64 `json:"reserved"`
+	Surplus    uint64 `json:"surplus"`
+	Overcommit uint64 `json:"overcommit"`
+}
+
+// HugePages represents huge page pools and transparent huge page (THP) settings
+type HugePages struct {
+	Pools []HugePagePool `json:"pools"`
+	// THPEnabled and THPDefrag are the selected modes, e.g. "madvise"
+	THPEnabled string `json:"thp_enabled"`
+	THPDefrag  string `json:"thp_defrag"`
+	// THPCounters holds the thp_* counters from /proc/vmstat
+	THPCounters map[string]uint64 `json:"thp_counters"`
+}
+
+const (
+	sysHugePagesPath = "/sys/kernel/mm/hugepages"
+	sysTHPPath       = "/sys/kernel/mm/transparent_hugepage"
+)
+
+// GetHugePages returns every huge page pool, the THP mode and defrag settings,
+// and the thp_* event counters
+func GetHugePages() (*HugePages, error) {
+	dirs, err := filepath.Glob(filepath.Join(sysHugePagesPath, "hugepages-*kB"))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &HugePages{}
+	for _, dir := range dirs {
+		sizeKB, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(dir), "hugepages-"), "kB"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		pool := HugePagePool{SizeBytes: sizeKB * 1024}
+		pool.Total, _ = readUint(filepath.Join(dir, "nr_hugepages"))
+		pool.Free, _ = readUint(filepath.Join(dir, "free_hugepages"))
+		pool.Reserved, _ = readUint(filepath.Join(dir, "resv_hugepages"))
+		pool.Surplus, _ = readUint(filepath.Join(dir, "surplus_hugepages"))
+		pool.Overcommit, _ = readUint(filepath.Join(dir, "nr_overcommit_hugepages"))
+		stats.Pools = append(stats.Pools, pool)
+	}
+	sort.Slice(stats.Pools, func(i, j int) bool {
+		return stats.Pools[i].SizeBytes < stats.Pools[j].SizeBytes
+	})
+
+	// THP is optional; kernels built without it have no transparent_hugepage directory
+	stats.THPEnabled, _ = readSelectedMode(filepath.Join(sysTHPPath, "enabled"))
+	stats.THPDefrag, _ = readSelectedMode(filepath.Join(sysTHPPath, "defrag"))
+
+	stats.THPCounters, err = readVMStatPrefix("thp_")
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// readSelectedMode returns the bracketed choice of a sysfs mode file such as "always [madvise] never"
+func readSelectedMode(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	for _, mode := range strings.Fields(string(data)) {
+		if strings.HasPrefix(mode, "[") && strings.HasSuffix(mode, "]") {
+			return strings.Trim(mode, "[]"), nil
+		}
+	}
+	return "", fmt.Errorf("no selected mode in %s", path)
+}
+
+// readVMStatPrefix returns the /proc/vmstat counters whose names start with prefix
+func readVMStatPrefix(prefix string) (map[string]uint64, error) {
+	file, err := os.Open("/proc/vmstat")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	counters := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || !strings.HasPrefix(fields[0], prefix) {
+			continue
+		}
+		counters[fields[0]], _ = strconv.ParseUint(fields[1], 10, 64)
+	}
+
+	return counters, scanner.Err()
+}
+
+func readUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
@@ -0,0 +1,32 @@
+//go:build windows
+// +build windows
+
+package memory
+
+// HugePagePool represents the reservation of one huge page size. Counts are in pages.
+type HugePagePool struct {
+	SizeBytes  uint64 `json:"size_bytes"`
+	Total      uint64 `json:"total"`
+	Free       uint64 `json:"free"`
+	Reserved   uint64 `json:"reserved"`
+	Surplus    uint64 `json:"surplus"`
+	Overcommit uint64 `json:"overcommit"`
+}
+
+// HugePages represents huge page pools and transparent huge page (THP) settings
+type HugePages struct {
+	Pools       []HugePagePool    `json:"pools"`
+	THPEnabled  string            `json:"thp_enabled"`
+	THPDefrag   string            `json:"thp_defrag"`
+	THPCounters map[string]uint64 `json:"thp_counters"`
+}
+
+// GetHugePages returns mock huge page statistics for Windows
+func GetHugePages() (*HugePages, error) {
+	return &HugePages{
+		Pools:       []HugePagePool{{SizeBytes: 2 * 1024 * 1024}},
+		THPEnabled:  "never",
+		THPDefrag:   "never",
+		THPCounters: map[string]uint64{},
+	}, nil
+}
@@ -0,0 +1,130 @@
+package memory
+
+// MemInfo represents every known field of /proc/meminfo.
+// Sizes are in bytes; the HugePages_* fields are page counts.
+type MemInfo struct {
+	MemTotal          uint64 `json:"mem_total"`
+	MemFree           uint64 `json:"mem_free"`
+	MemAvailable      uint64 `json:"mem_available"`
+	Buffers           uint64 `json:"buffers"`
+	Cached            uint64 `json:"cached"`
+	SwapCached        uint64 `json:"swap_cached"`
+	Active            uint64 `json:"active"`
+	Inactive          uint64 `json:"inactive"`
+	ActiveAnon        uint64 `json:"active_anon"`
+	InactiveAnon      uint64 `json:"inactive_anon"`
+	ActiveFile        uint64 `json:"active_file"`
+	InactiveFile      uint64 `json:"inactive_file"`
+	Unevictable       uint64 `json:"unevictable"`
+	Mlocked           uint64 `json:"mlocked"`
+	SwapTotal         uint64 `json:"swap_total"`
+	SwapFree          uint64 `json:"swap_free"`
+	Zswap             uint64 `json:"zswap"`
+	Zswapped          uint64 `json:"zswapped"`
+	Dirty             uint64 `json:"dirty"`
+	Writeback         uint64 `json:"writeback"`
+	AnonPages         uint64 `json:"anon_pages"`
+	Mapped            uint64 `json:"mapped"`
+	Shmem             uint64 `json:"shmem"`
+	KReclaimable      uint64 `json:"kreclaimable"`
+	Slab              uint64 `json:"slab"`
+	SReclaimable      uint64 `json:"sreclaimable"`
+	SUnreclaim        uint64 `json:"sunreclaim"`
+	KernelStack       uint64 `json:"kernel_stack"`
+	PageTables        uint64 `json:"page_tables"`
+	SecPageTables     uint64 `json:"sec_page_tables"`
+	NFSUnstable       uint64 `json:"nfs_unstable"`
+	Bounce            uint64 `json:"bounce"`
+	WritebackTmp      uint64 `json:"writeback_tmp"`
+	CommitLimit       uint64 `json:"commit_limit"`
+	CommittedAS       uint64 `json:"committed_as"`
+	VmallocTotal      uint64 `json:"vmalloc_total"`
+	VmallocUsed       uint64 `json:"vmalloc_used"`
+	VmallocChunk      uint64 `json:"vmalloc_chunk"`
+	Percpu            uint64 `json:"percpu"`
+	HardwareCorrupted uint64 `json:"hardware_corrupted"`
+	AnonHugePages     uint64 `json:"anon_huge_pages"`
+	ShmemHugePages    uint64 `json:"shmem_huge_pages"`
+	ShmemPmdMapped    uint64 `json:"shmem_pmd_mapped"`
+	FileHugePages     uint64 `json:"file_huge_pages"`
+	FilePmdMapped     uint64 `json:"file_pmd_mapped"`
+	CmaTotal          uint64 `json:"cma_total"`
+	CmaFree           uint64 `json:"cma_free"`
+	Balloon           uint64 `json:"balloon"`
+	HugePagesTotal    uint64 `json:"huge_pages_total"`
+	HugePagesFree     uint64 `json:"huge_pages_free"`
+	HugePagesRsvd     uint64 `json:"huge_pages_rsvd"`
+	HugePagesSurp     uint64 `json:"huge_pages_surp"`
+	Hugepagesize      uint64 `json:"hugepagesize"`
+	Hugetlb           uint64 `json:"hugetlb"`
+	DirectMap4k       uint64 `json:"direct_map_4k"`
+	DirectMap2M       uint64 `json:"direct_map_2m"`
+	DirectMap1G       uint64 `json:"direct_map_1g"`
+
+	// Raw holds every key exactly as named in /proc/meminfo, including ones
+	// not covered above. Values with a kB unit are converted to bytes.
+	Raw map[string]uint64 `json:"raw"`
+}
+
+// fieldPointers maps /proc/meminfo keys to the typed fields of info
+func (info *MemInfo) fieldPointers() map[string]*uint64 {
+	return map[string]*uint64{
+		"MemTotal":          &info.MemTotal,
+		"MemFree":           &info.MemFree,
+		"MemAvailable":      &info.MemAvailable,
+		"Buffers":           &info.Buffers,
+		"Cached":            &info.Cached,
+		"SwapCached":        &info.SwapCached,
+		"Active":            &info.Active,
+		"Inactive":          &info.Inactive,
+		"Active(anon)":      &info.ActiveAnon,
+		"Inactive(anon)":    &info.InactiveAnon,
+		"Active(file)":      &info.ActiveFile,
+		"Inactive(file)":    &info.InactiveFile,
+		"Unevictable":       &info.Unevictable,
+		"Mlocked":           &info.Mlocked,
+		"SwapTotal":         &info.SwapTotal,
+		"SwapFree":          &info.SwapFree,
+		"Zswap":             &info.Zswap,
+		"Zswapped":          &info.Zswapped,
+		"Dirty":             &info.Dirty,
+		"Writeback":         &info.Writeback,
+		"AnonPages":         &info.AnonPages,
+		"Mapped":            &info.Mapped,
+		"Shmem":             &info.Shmem,
+		"KReclaimable":      &info.KReclaimable,
+		"Slab":              &info.Slab,
+		"SReclaimable":      &info.SReclaimable,
+		"SUnreclaim":        &info.SUnreclaim,
+		"KernelStack":       &info.KernelStack,
+		"PageTables":        &info.PageTables,
+		"SecPageTables":     &info.SecPageTables,
+		"NFS_Unstable":      &info.NFSUnstable,
+		"Bounce":            &info.Bounce,
+		"WritebackTmp":      &info.WritebackTmp,
+		"CommitLimit":       &info.CommitLimit,
+		"Committed_AS":      &info.CommittedAS,
+		"VmallocTotal":      &info.VmallocTotal,
+		"VmallocUsed":       &info.VmallocUsed,
+		"VmallocChunk":      &info.VmallocChunk,
+		"Percpu":            &info.Percpu,
+		"HardwareCorrupted": &info.HardwareCorrupted,
+		"AnonHugePages":     &info.AnonHugePages,
+		"ShmemHugePages":    &info.ShmemHugePages,
+		"ShmemPmdMapped":    &info.ShmemPmdMapped,
+		"FileHugePages":     &info.FileHugePages,
+		"FilePmdMapped":     &info.FilePmdMapped,
+		"CmaTotal":          &info.CmaTotal,
+		"CmaFree":           &info.CmaFree,
+		"Balloon":           &info.Balloon,
+		"HugePages_Total":   &info.HugePagesTotal,
+		"HugePages_Free":    &info.HugePagesFree,
+		"HugePages_Rsvd":    &info.HugePagesRsvd,
+		"HugePages_Surp":    &info.HugePagesSurp,
+		"Hugepagesize":      &info.Hugepagesize,
+		"Hugetlb":           &info.Hugetlb,
+		"DirectMap4k":       &info.DirectMap4k,
+		"DirectMap2M":       &info.DirectMap2M,
+		"DirectMap1G":       &info.DirectMap1G,
+	}
+}
@@ -1,3 +1,4 @@
+//go:build linux
 // +build linux
 
 package memory
@@ -11,68 +12,86 @@ import (
 
 // MemoryStats represents memory statistics from /proc/meminfo
 type MemoryStats struct {
-	Total     uint64
-	Used      uint64
-	Free      uint64
-	Buffers   uint64
-	Cached    uint64
-	Active    uint64
-	Inactive  uint64
-	SwapTotal uint64
-	SwapUsed  uint64
-	SwapFree  uint64
+	Total        uint64
+	Used         uint64
+	Free         uint64
+	Available    uint64
+	Buffers      uint64
+	Cached       uint64
+	SReclaimable uint64
+	Shmem        uint64
+	Active       uint64
+	Inactive     uint64
+	SwapTotal    uint64
+	SwapUsed     uint64
+	SwapFree     uint64
 }
 
 // GetMemory returns memory statistics from /proc/meminfo
 func GetMemory() (*MemoryStats, error) {
+	info, err := GetMemInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &MemoryStats{
+		Total:        info.MemTotal,
+		Free:         info.MemFree,
+		Available:    info.MemAvailable,
+		Buffers:      info.Buffers,
+		Cached:       info.Cached,
+		SReclaimable: info.SReclaimable,
+		Shmem:        info.Shmem,
+		Active:       info.Active,
+		Inactive:     info.Inactive,
+		SwapTotal:    info.SwapTotal,
+		SwapFree:     info.SwapFree,
+	}
+
+	// Match free(1): used is whatever is not available. Kernels before 3.14 lack
+	// MemAvailable, so estimate it from free memory, buffers and reclaimable cache.
+	if _, ok := info.Raw["MemAvailable"]; !ok {
+		stats.Available = stats.Free + stats.Buffers + stats.Cached + stats.SReclaimable
+	}
+	stats.Used = subtract(stats.Total, stats.Available)
+	stats.SwapUsed = subtract(stats.SwapTotal, stats.SwapFree)
+
+	return stats, nil
+}
+
+// GetMemInfo returns every field of /proc/meminfo, both typed and as a raw map
+func GetMemInfo() (*MemInfo, error) {
 	file, err := os.Open("/proc/meminfo")
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	stats := &MemoryStats{}
+	info := &MemInfo{Raw: make(map[string]uint64)}
+	fields := info.fieldPointers()
 	scanner := bufio.NewScanner(file)
-	
-    // We strictly need units to be in kB as per standard, or handle parsing logic.
-    // Standard /proc/meminfo is in kB.
-    
-    for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Fields(line)
-        if len(parts) < 2 {
-            continue
-        }
-        
-        // Remove trailing colon from key
-        key := strings.TrimSuffix(parts[0], ":")
-        valString := parts[1]
-        
-        val, err := strconv.ParseUint(valString, 10, 64)
-        if err != nil {
-            continue 
-        }
-        
-        // Convert kB to Bytes
-        valBytes := val * 1024
-
-		switch key {
-		case "MemTotal":
-			stats.Total = valBytes
-		case "MemFree":
-			stats.Free = valBytes
-		case "Buffers":
-			stats.Buffers = valBytes
-		case "Cached":
-			stats.Cached = valBytes
-		case "Active":
-			stats.Active = valBytes
-		case "Inactive":
-			stats.Inactive = valBytes
-		case "SwapTotal":
-			stats.SwapTotal = valBytes
-		case "SwapFree":
-			stats.SwapFree = valBytes
+
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) < 2 {
+			continue
+		}
+
+		// Remove trailing colon from key
+		key := strings.TrimSuffix(parts[0], ":")
+		val, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		// Sizes are reported in kB; HugePages_* counts have no unit
+		if len(parts) > 2 && parts[2] == "kB" {
+			val *= 1024
+		}
+
+		info.Raw[key] = val
+		if field, ok := fields[key]; ok {
+			*field = val
 		}
 	}
 
@@ -80,8 +99,13 @@ func GetMemory() (*MemoryStats, error) {
 		return nil, err
 	}
 
-    stats.Used = stats.Total - stats.Free - stats.Buffers - stats.Cached
-    stats.SwapUsed = stats.SwapTotal - stats.SwapFree
+	return info, nil
+}
 
-	return stats, nil
+// subtract returns a - b, clamped at zero
+func subtract(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
 }
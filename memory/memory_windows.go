@@ -1,26 +1,46 @@
+//go:build windows
 // +build windows
 
 package memory
 
 // MemoryStats represents memory statistics
 type MemoryStats struct {
-	Total     uint64
-	Used      uint64
-	Free      uint64
-	Buffers   uint64
-	Cached    uint64
-	Active    uint64
-	Inactive  uint64
-	SwapTotal uint64
-	SwapUsed  uint64
-	SwapFree  uint64
+	Total        uint64
+	Used         uint64
+	Free         uint64
+	Available    uint64
+	Buffers      uint64
+	Cached       uint64
+	SReclaimable uint64
+	Shmem        uint64
+	Active       uint64
+	Inactive     uint64
+	SwapTotal    uint64
+	SwapUsed     uint64
+	SwapFree     uint64
 }
 
 // GetMemory returns mock memory statistics for Windows
 func GetMemory() (*MemoryStats, error) {
 	return &MemoryStats{
-		Total: 16 * 1024 * 1024 * 1024,
-		Used:  8 * 1024 * 1024 * 1024,
-		Free:  8 * 1024 * 1024 * 1024,
+		Total:     16 * 1024 * 1024 * 1024,
+		Used:      8 * 1024 * 1024 * 1024,
+		Free:      8 * 1024 * 1024 * 1024,
+		Available: 8 * 1024 * 1024 * 1024,
 	}, nil
 }
+
+// GetMemInfo returns mock meminfo fields for Windows
+func GetMemInfo() (*MemInfo, error) {
+	info := &MemInfo{
+		MemTotal:     16 * 1024 * 1024 * 1024,
+		MemFree:      8 * 1024 * 1024 * 1024,
+		MemAvailable: 8 * 1024 * 1024 * 1024,
+	}
+	info.Raw = map[string]uint64{
+		"MemTotal":     info.MemTotal,
+		"MemFree":      info.MemFree,
+		"MemAvailable": info.MemAvailable,
+	}
+	return info, nil
+}
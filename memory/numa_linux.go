@@ -0,0 +1,153 @@
+//go:build linux
+// +build linux
+
+package memory
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/avirooppal/gosysutil/cpu"
+)
+
+// NUMANode represents memory, allocation counters and CPUs of one NUMA node
+// from /sys/devices/system/node/nodeN
+type NUMANode struct {
+	ID       int    `json:"id"`
+	MemTotal uint64 `json:"mem_total"`
+	MemFree  uint64 `json:"mem_free"`
+	MemUsed  uint64 `json:"mem_used"`
+	// MemInfo holds every field of the node's meminfo in bytes (HugePages_* as page counts)
+	MemInfo map[string]uint64 `json:"meminfo"`
+
+	NumaHit       uint64 `json:"numa_hit"`
+	NumaMiss      uint64 `json:"numa_miss"`
+	NumaForeign   uint64 `json:"numa_foreign"`
+	InterleaveHit uint64 `json:"interleave_hit"`
+	LocalNode     uint64 `json:"local_node"`
+	OtherNode     uint64 `json:"other_node"`
+
+	CPUs []int `json:"cpus"`
+	// Distances is this node's row of the distance matrix, ordered like the
+	// slice returned by GetNUMANodes (online nodes by ID)
+	Distances []int `json:"distances"`
+}
+
+const sysNodePath = "/sys/devices/system/node"
+
+// GetNUMANodes returns per-node memory, hit/miss counters, CPUs and distances.
+// Kernels built without CONFIG_NUMA have no node directories, which yields an empty slice.
+func GetNUMANodes() ([]NUMANode, error) {
+	dirs, err := filepath.Glob(filepath.Join(sysNodePath, "node[0-9]*"))
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]NUMANode, 0, len(dirs))
+	for _, dir := range dirs {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(dir), "node"))
+		if err != nil {
+			continue
+		}
+
+		node := NUMANode{ID: id}
+		node.MemInfo, err = readNodeMemInfo(filepath.Join(dir, "meminfo"))
+		if err != nil {
+			return nil, err
+		}
+		node.MemTotal = node.MemInfo["MemTotal"]
+		node.MemFree = node.MemInfo["MemFree"]
+		node.MemUsed = node.MemInfo["MemUsed"]
+
+		if err := readNumaStat(filepath.Join(dir, "numastat"), &node); err != nil {
+			return nil, err
+		}
+
+		if data, err := os.ReadFile(filepath.Join(dir, "cpulist")); err == nil {
+			node.CPUs, _ = cpu.ParseList(string(data))
+		}
+
+		if data, err := os.ReadFile(filepath.Join(dir, "distance")); err == nil {
+			for _, field := range strings.Fields(string(data)) {
+				d, _ := strconv.Atoi(field)
+				node.Distances = append(node.Distances, d)
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+
+	return nodes, nil
+}
+
+// readNodeMemInfo parses lines of the form "Node 0 MemTotal:  4816632 kB"
+func readNodeMemInfo(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+
+		key := strings.TrimSuffix(fields[2], ":")
+		val, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			continue
+		}
+		if len(fields) > 4 && fields[4] == "kB" {
+			val *= 1024
+		}
+		info[key] = val
+	}
+
+	return info, scanner.Err()
+}
+
+func readNumaStat(path string, node *NUMANode) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		val, _ := strconv.ParseUint(fields[1], 10, 64)
+		switch fields[0] {
+		case "numa_hit":
+			node.NumaHit = val
+		case "numa_miss":
+			node.NumaMiss = val
+		case "numa_foreign":
+			node.NumaForeign = val
+		case "interleave_hit":
+			node.InterleaveHit = val
+		case "local_node":
+			node.LocalNode = val
+		case "other_node":
+			node.OtherNode = val
+		}
+	}
+
+	return scanner.Err()
+}
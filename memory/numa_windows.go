@@ -0,0 +1,37 @@
+//go:build windows
+// +build windows
+
+package memory
+
+// NUMANode represents memory, allocation counters and CPUs of one NUMA node
+type NUMANode struct {
+	ID       int               `json:"id"`
+	MemTotal uint64            `json:"mem_total"`
+	MemFree  uint64            `json:"mem_free"`
+	MemUsed  uint64            `json:"mem_used"`
+	MemInfo  map[string]uint64 `json:"meminfo"`
+
+	NumaHit       uint64 `json:"numa_hit"`
+	NumaMiss      uint64 `json:"numa_miss"`
+	NumaForeign   uint64 `json:"numa_foreign"`
+	InterleaveHit uint64 `json:"interleave_hit"`
+	LocalNode     uint64 `json:"local_node"`
+	OtherNode     uint64 `json:"other_node"`
+
+	CPUs      []int `json:"cpus"`
+	Distances []int `json:"distances"`
+}
+
+// GetNUMANodes returns a mock single NUMA node for Windows
+func GetNUMANodes() ([]NUMANode, error) {
+	return []NUMANode{
+		{
+			ID:        0,
+			MemTotal:  16 * 1024 * 1024 * 1024,
+			MemFree:   8 * 1024 * 1024 * 1024,
+			MemUsed:   8 * 1024 * 1024 * 1024,
+			CPUs:      []int{0, 1},
+			Distances: []int{10},
+		},
+	}, nil
+}
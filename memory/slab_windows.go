@@ -0,0 +1,36 @@
+//go:build windows
+// +build windows
+
+package memory
+
+// SlabCache represents one kernel object cache
+type SlabCache struct {
+	Name         string `json:"name"`
+	ActiveObjs   uint64 `json:"active_objs"`
+	NumObjs      uint64 `json:"num_objs"`
+	ObjSize      uint64 `json:"obj_size"`
+	ObjPerSlab   uint64 `json:"obj_per_slab"`
+	PagesPerSlab uint64 `json:"pages_per_slab"`
+	ActiveSlabs  uint64 `json:"active_slabs"`
+	NumSlabs     uint64 `json:"num_slabs"`
+	TotalBytes   uint64 `json:"total_bytes"`
+	ActiveBytes  uint64 `json:"active_bytes"`
+}
+
+// SlabInfo represents the slab allocator breakdown
+type SlabInfo struct {
+	Caches       []SlabCache `json:"caches"`
+	Detailed     bool        `json:"detailed"`
+	Total        uint64      `json:"total"`
+	SReclaimable uint64      `json:"sreclaimable"`
+	SUnreclaim   uint64      `json:"sunreclaim"`
+}
+
+// GetSlabInfo returns mock slab statistics for Windows
+func GetSlabInfo() (*SlabInfo, error) {
+	return &SlabInfo{
+		Total:        64 * 1024 * 1024,
+		SReclaimable: 48 * 1024 * 1024,
+		SUnreclaim:   16 * 1024 * 1024,
+	}, nil
+}
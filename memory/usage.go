@@ -0,0 +1,39 @@
+package memory
+
+// Usage represents memory utilisation percentages derived from MemoryStats.
+// The API and the TUI both report these so every view of a host agrees.
+type Usage struct {
+	// UsedPercent is Used / Total, where Used excludes reclaimable cache (like free(1))
+	UsedPercent float64 `json:"used_percent"`
+	// AvailablePercent is Available / Total
+	AvailablePercent float64 `json:"available_percent"`
+	// CacheInclusiveUsedPercent is (Total - Free) / Total, counting buffers and cache as used
+	CacheInclusiveUsedPercent float64 `json:"cache_inclusive_used_percent"`
+	// SwapUsedPercent is SwapUsed / SwapTotal, or 0 without swap
+	SwapUsedPercent float64 `json:"swap_used_percent"`
+}
+
+// Usage computes the utilisation percentages of s
+func (s *MemoryStats) Usage() *Usage {
+	usage := &Usage{}
+	if s.Total > 0 {
+		usage.UsedPercent = float64(s.Used) / float64(s.Total) * 100
+		usage.AvailablePercent = float64(s.Available) / float64(s.Total) * 100
+		if s.Free <= s.Total {
+			usage.CacheInclusiveUsedPercent = float64(s.Total-s.Free) / float64(s.Total) * 100
+		}
+	}
+	if s.SwapTotal > 0 {
+		usage.SwapUsedPercent = float64(s.SwapUsed) / float64(s.SwapTotal) * 100
+	}
+	return usage
+}
+
+// GetUsage returns the current memory utilisation percentages
+func GetUsage() (*Usage, error) {
+	stats, err := GetMemory()
+	if err != nil {
+		return nil, err
+	}
+	return stats.Usage(), nil
+}
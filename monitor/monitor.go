@@ -10,11 +10,14 @@ import (
 
 // SystemStats aggregates all system statistics
 type SystemStats struct {
-	CPU     *cpu.CPUStats
-	Memory  *memory.MemoryStats
-	Disks   []disk.DiskStats
-	Network []network.NetworkStats
-	Processes []process.Process
+	CPU         *cpu.CPUStats
+	PerCPU      []cpu.CPUStats
+	CPUFreq     []cpu.CPUFrequency
+	Memory      *memory.MemoryStats
+	Disks       []disk.DiskStats
+	Filesystems []disk.Filesystem
+	Network     []network.NetworkStats
+	Processes   []process.Process
 }
 
 // GetSystemStats collects all available system statistics.
@@ -29,6 +32,14 @@ func GetSystemStats() (*SystemStats, error) {
 		return nil, err
 	}
 
+	stats.PerCPU, err = cpu.GetPerCPU()
+	if err != nil {
+		return nil, err
+	}
+
+	// Frequency data is optional (some containers hide /sys), so a failure is not fatal
+	stats.CPUFreq, _ = cpu.GetFrequencies()
+
 	stats.Memory, err = memory.GetMemory()
 	if err != nil {
 		return nil, err
@@ -39,6 +50,10 @@ func GetSystemStats() (*SystemStats, error) {
 		return nil, err
 	}
 
+	// Network and FUSE mounts are skipped by default, so statfs cannot stall the
+	// refresh on an unreachable server; mountinfo may still be unreadable
+	stats.Filesystems, _ = disk.GetFilesystems()
+
 	stats.Network, err = network.GetNetwork()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,58 @@
+package network
+
+import "net"
+
+// Address represents an IP address assigned to an interface
+type Address struct {
+	Interface string `json:"interface"`
+	// Family is "inet" or "inet6"
+	Family    string `json:"family"`
+	Address   string `json:"address"`
+	PrefixLen int    `json:"prefix_len"`
+	// Scope is "host" for loopback, "link" for link-local and "global" otherwise
+	Scope string `json:"scope"`
+}
+
+// GetAddresses returns the IPv4 and IPv6 addresses of every interface
+func GetAddresses() ([]Address, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := []Address{}
+	for _, iface := range interfaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+
+			a := Address{
+				Interface: iface.Name,
+				Family:    "inet6",
+				Address:   ipNet.IP.String(),
+				Scope:     "global",
+			}
+			a.PrefixLen, _ = ipNet.Mask.Size()
+			if ipNet.IP.To4() != nil {
+				a.Family = "inet"
+			}
+			switch {
+			case ipNet.IP.IsLoopback():
+				a.Scope = "host"
+			case ipNet.IP.IsLinkLocalUnicast():
+				a.Scope = "link"
+			}
+
+			addresses = append(addresses, a)
+		}
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,308 @@
+//go:build linux
+// +build linux
+
+package network
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Connection represents one socket from /proc/net/{tcp,tcp6,udp,udp6,unix}
+type Connection struct {
+	// Proto is "tcp", "tcp6", "udp", "udp6" or "unix"
+	Proto      string `json:"proto"`
+	LocalAddr  string `json:"local_addr"`
+	LocalPort  int    `json:"local_port"`
+	RemoteAddr string `json:"remote_addr"`
+	RemotePort int    `json:"remote_port"`
+	// State is the TCP state (ESTABLISHED, LISTEN, TIME_WAIT, ...). Unconnected
+	// UDP sockets are UNCONN; unix sockets are LISTEN, CONNECTED, UNCONN, ...
+	State string `json:"state"`
+	// For TCP, TxQueue is the bytes sent but not yet acknowledged by the peer
+	// (write_seq - snd_una) and RxQueue the bytes received but not yet read;
+	// on LISTEN sockets RxQueue is the number of connections awaiting accept().
+	// For UDP both are the bytes held in the socket's send and receive buffers.
+	TxQueue uint64 `json:"tx_queue"`
+	RxQueue uint64 `json:"rx_queue"`
+	// UID is the socket owner; /proc/net/unix does not report it
+	UID   uint32 `json:"uid"`
+	Inode uint64 `json:"inode"`
+	// Path is the bound path of a unix socket ("@name" for abstract sockets)
+	Path string `json:"path,omitempty"`
+	// PID and Process are 0 and "" when the owner could not be found,
+	// typically because its /proc/<pid>/fd is not readable without root
+	PID     int    `json:"pid"`
+	Process string `json:"process"`
+}
+
+// tcpStates maps the st column to names, see include/net/tcp_states.h
+var tcpStates = map[string]string{
+	"01": "ESTABLISHED",
+	"02": "SYN_SENT",
+	"03": "SYN_RECV",
+	"04": "FIN_WAIT1",
+	"05": "FIN_WAIT2",
+	"06": "TIME_WAIT",
+	"07": "CLOSE",
+	"08": "CLOSE_WAIT",
+	"09": "LAST_ACK",
+	"0A": "LISTEN",
+	"0B": "CLOSING",
+	"0C": "NEW_SYN_RECV",
+}
+
+// unixStates maps the St column of /proc/net/unix (socket_state in include/uapi/linux/net.h)
+var unixStates = map[string]string{
+	"01": "UNCONN",
+	"02": "CONNECTING",
+	"03": "CONNECTED",
+	"04": "DISCONNECTING",
+}
+
+// protoFiles lists the tables read for each proto accepted by GetConnections
+var protoFiles = map[string][]string{
+	"tcp":  {"tcp", "tcp6"},
+	"tcp4": {"tcp"},
+	"tcp6": {"tcp6"},
+	"udp":  {"udp", "udp6"},
+	"udp4": {"udp"},
+	"udp6": {"udp6"},
+	"inet": {"tcp", "tcp6", "udp", "udp6"},
+	"unix": {"unix"},
+	"all":  {"tcp", "tcp6", "udp", "udp6", "unix"},
+}
+
+// GetConnections returns the sockets of proto, one of "tcp", "tcp4", "tcp6",
+// "udp", "udp4", "udp6", "inet", "unix" or "all" ("tcp" and "udp" include
+// IPv6), with each socket's owning PID and process name
+func GetConnections(proto string) ([]Connection, error) {
+	files, ok := protoFiles[proto]
+	if !ok {
+		return nil, fmt.Errorf("unknown protocol %q", proto)
+	}
+
+	connections := []Connection{}
+	for _, name := range files {
+		var conns []Connection
+		var err error
+		if name == "unix" {
+			conns, err = readUnixSockets()
+		} else {
+			conns, err = readInetSockets(name)
+		}
+		if err != nil {
+			// tcp6 and udp6 are missing when IPv6 is disabled
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		connections = append(connections, conns...)
+	}
+
+	owners := socketOwners()
+	for i := range connections {
+		if owner, ok := owners[connections[i].Inode]; ok {
+			connections[i].PID = owner.pid
+			connections[i].Process = owner.name
+		}
+	}
+
+	return connections, nil
+}
+
+func readInetSockets(name string) ([]Connection, error) {
+	file, err := os.Open(filepath.Join("/proc/net", name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseInetSockets(name, file)
+}
+
+// parseInetSockets parses the content of /proc/net/<name> for tcp, tcp6, udp or udp6
+func parseInetSockets(name string, r io.Reader) ([]Connection, error) {
+	var connections []Connection
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // header
+
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode ...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+
+		c := Connection{Proto: name}
+		var err error
+		if c.LocalAddr, c.LocalPort, err = parseHexAddr(fields[1]); err != nil {
+			return nil, fmt.Errorf("invalid address in /proc/net/%s: %q", name, fields[1])
+		}
+		if c.RemoteAddr, c.RemotePort, err = parseHexAddr(fields[2]); err != nil {
+			return nil, fmt.Errorf("invalid address in /proc/net/%s: %q", name, fields[2])
+		}
+
+		c.State = tcpStates[fields[3]]
+		if strings.HasPrefix(name, "udp") && fields[3] == "07" {
+			c.State = "UNCONN"
+		}
+
+		if tx, rx, ok := strings.Cut(fields[4], ":"); ok {
+			c.TxQueue, _ = strconv.ParseUint(tx, 16, 64)
+			c.RxQueue, _ = strconv.ParseUint(rx, 16, 64)
+		}
+		uid, _ := strconv.ParseUint(fields[7], 10, 32)
+		c.UID = uint32(uid)
+		c.Inode, _ = strconv.ParseUint(fields[9], 10, 64)
+
+		connections = append(connections, c)
+	}
+
+	return connections, scanner.Err()
+}
+
+func readUnixSockets() ([]Connection, error) {
+	file, err := os.Open("/proc/net/unix")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseUnixSockets(file)
+}
+
+// parseUnixSockets parses the content of /proc/net/unix
+func parseUnixSockets(r io.Reader) ([]Connection, error) {
+	var connections []Connection
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // header
+
+	for scanner.Scan() {
+		// Num RefCount Protocol Flags Type St Inode Path
+		fields, path := cutFields(scanner.Text(), 7)
+		if len(fields) < 7 {
+			continue
+		}
+
+		c := Connection{Proto: "unix", State: unixStates[fields[5]]}
+		// __SO_ACCEPTCON marks a listening socket
+		if flags, _ := strconv.ParseUint(fields[3], 16, 32); flags&0x10000 != 0 {
+			c.State = "LISTEN"
+		}
+		c.Inode, _ = strconv.ParseUint(fields[6], 10, 64)
+		c.Path = path
+
+		connections = append(connections, c)
+	}
+
+	return connections, scanner.Err()
+}
+
+// cutFields splits the first n space-separated fields off line and returns them
+// with the rest of the line, which is kept verbatim so paths keep their spaces
+func cutFields(line string, n int) ([]string, string) {
+	fields := make([]string, 0, n)
+	rest := line
+	for len(fields) < n {
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			return fields, ""
+		}
+		field, after, found := strings.Cut(rest, " ")
+		fields = append(fields, field)
+		if !found {
+			return fields, ""
+		}
+		rest = after
+	}
+	return fields, rest
+}
+
+// parseHexAddr decodes "0100007F:0035" (IPv4) or a 32-digit IPv6 address and
+// port. Addresses are printed as 32-bit words in host byte order.
+func parseHexAddr(s string) (string, int, error) {
+	addr, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return "", 0, fmt.Errorf("missing port")
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return "", 0, err
+	}
+
+	raw, err := hex.DecodeString(addr)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", 0, fmt.Errorf("invalid address")
+	}
+
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.NativeEndian.PutUint32(ip[i:], binary.BigEndian.Uint32(raw[i:]))
+	}
+
+	return ip.String(), int(port), nil
+}
+
+type socketOwner struct {
+	pid  int
+	name string
+}
+
+// socketOwners maps socket inodes to the process holding them by reading the
+// socket:[inode] links in /proc/<pid>/fd. Processes whose fd directory cannot
+// be read are skipped.
+func socketOwners() map[uint64]socketOwner {
+	owners := make(map[uint64]socketOwner)
+
+	pids, err := os.ReadDir("/proc")
+	if err != nil {
+		return owners
+	}
+
+	for _, entry := range pids {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		fdDir := filepath.Join("/proc", entry.Name(), "fd")
+		fds, err := os.ReadDir(fdDir)
+		if err != nil {
+			continue
+		}
+
+		name := ""
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			inode, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]"), 10, 64)
+			if err != nil {
+				continue
+			}
+			if _, seen := owners[inode]; seen {
+				// Shared after fork; keep the first process found
+				continue
+			}
+			if name == "" {
+				if comm, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "comm")); err == nil {
+					name = strings.TrimSpace(string(comm))
+				}
+			}
+			owners[inode] = socketOwner{pid: pid, name: name}
+		}
+	}
+
+	return owners
+}
@@ -0,0 +1,151 @@
+//go:build linux
+// +build linux
+
+package network
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// The fixtures are /proc/net lines from a little-endian host
+func skipOnBigEndian(t *testing.T) {
+	if binary.NativeEndian.Uint16([]byte{1, 0}) != 1 {
+		t.Skip("fixtures use little-endian address words")
+	}
+}
+
+func TestParseHexAddr(t *testing.T) {
+	skipOnBigEndian(t)
+
+	tests := []struct {
+		input   string
+		addr    string
+		port    int
+		wantErr bool
+	}{
+		{input: "0100007F:0035", addr: "127.0.0.1", port: 53},
+		{input: "00000000:1F90", addr: "0.0.0.0", port: 8080},
+		{input: "0F02000A:D5A8", addr: "10.0.2.15", port: 54696},
+		{input: "00000000000000000000000001000000:0277", addr: "::1", port: 631},
+		{input: "00000000000000000000000000000000:0016", addr: "::", port: 22},
+		{input: "000080FE000000000000000001000000:0222", addr: "fe80::1", port: 546},
+		{input: "B80D0120000000000000000001000000:01BB", addr: "2001:db8::1", port: 443},
+		// IPv4-mapped addresses of dual-stack sockets print as plain IPv4
+		{input: "0000000000000000FFFF00000100007F:1F90", addr: "127.0.0.1", port: 8080},
+		{input: "0100007F", wantErr: true},
+		{input: "0100007F:ZZZZ", wantErr: true},
+		{input: "0100007G:0035", wantErr: true},
+		{input: "0100007F00:0035", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		addr, port, err := parseHexAddr(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseHexAddr(%q) = %s, %d, want an error", tt.input, addr, port)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseHexAddr(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if addr != tt.addr || port != tt.port {
+			t.Errorf("parseHexAddr(%q) = %s, %d, want %s, %d", tt.input, addr, port, tt.addr, tt.port)
+		}
+	}
+}
+
+func TestParseInetSockets(t *testing.T) {
+	skipOnBigEndian(t)
+
+	tests := []struct {
+		name  string
+		proto string
+		input string
+		want  []Connection
+	}{
+		{
+			name:  "tcp",
+			proto: "tcp",
+			input: `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0100007F:0035 00000000:0000 0A 00000000:00000003 00:00000000 00000000   101        0 21405 1 0000000000000000 100 0 0 10 0
+   1: 0F02000A:0016 0202000A:D5A8 01 00000024:00000000 01:00000015 00000000  1000        0 36811 4 0000000000000000 20 4 31 10 -1
+`,
+			want: []Connection{
+				{Proto: "tcp", LocalAddr: "127.0.0.1", LocalPort: 53, RemoteAddr: "0.0.0.0", State: "LISTEN", RxQueue: 3, UID: 101, Inode: 21405},
+				{Proto: "tcp", LocalAddr: "10.0.2.15", LocalPort: 22, RemoteAddr: "10.0.2.2", RemotePort: 54696, State: "ESTABLISHED", TxQueue: 0x24, UID: 1000, Inode: 36811},
+			},
+		},
+		{
+			name:  "tcp6",
+			proto: "tcp6",
+			input: `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000000000000:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 19321 1 0000000000000000 100 0 0 10 0
+   1: 00000000000000000000000001000000:0277 00000000000000000000000001000000:C350 06 00000000:00000000 03:00000F9E 00000000     0        0 0 3 0000000000000000
+`,
+			want: []Connection{
+				{Proto: "tcp6", LocalAddr: "::", LocalPort: 22, RemoteAddr: "::", State: "LISTEN", Inode: 19321},
+				{Proto: "tcp6", LocalAddr: "::1", LocalPort: 631, RemoteAddr: "::1", RemotePort: 50000, State: "TIME_WAIT"},
+			},
+		},
+		{
+			name:  "udp",
+			proto: "udp",
+			input: `   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  331: 00000000:0044 00000000:0000 07 00000000:00000340 00:00000000 00000000     0        0 15200 2 0000000000000000 0
+`,
+			want: []Connection{
+				{Proto: "udp", LocalAddr: "0.0.0.0", LocalPort: 68, RemoteAddr: "0.0.0.0", State: "UNCONN", RxQueue: 0x340, Inode: 15200},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseInetSockets(tt.proto, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d connections, want %d", len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("connection %d:\ngot  %+v\nwant %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseUnixSockets(t *testing.T) {
+	input := `Num       RefCount Protocol Flags    Type St Inode Path
+0000000000000000: 00000002 00000000 00010000 0001 01 23456 /run/systemd/notify
+0000000000000000: 00000003 00000000 00000000 0001 03    42
+0000000000000000: 00000002 00000000 00010000 0001 01 24680 @/tmp/.X11-unix/X0
+0000000000000000: 00000002 00000000 00010000 0001 01 13579 /run/user/1000/my  socket dir/app.sock
+` + "0000000000000000: 00000002 00000000 00000000 0002 01 11111 /tmp/trailing space \n"
+	want := []Connection{
+		{Proto: "unix", State: "LISTEN", Inode: 23456, Path: "/run/systemd/notify"},
+		{Proto: "unix", State: "CONNECTED", Inode: 42},
+		{Proto: "unix", State: "LISTEN", Inode: 24680, Path: "@/tmp/.X11-unix/X0"},
+		{Proto: "unix", State: "LISTEN", Inode: 13579, Path: "/run/user/1000/my  socket dir/app.sock"},
+		{Proto: "unix", State: "UNCONN", Inode: 11111, Path: "/tmp/trailing space "},
+	}
+
+	got, err := parseUnixSockets(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d sockets, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("socket %d:\ngot  %+v\nwant %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,36 @@
+//go:build windows
+// +build windows
+
+package network
+
+import "fmt"
+
+// Connection represents one socket
+type Connection struct {
+	Proto      string `json:"proto"`
+	LocalAddr  string `json:"local_addr"`
+	LocalPort  int    `json:"local_port"`
+	RemoteAddr string `json:"remote_addr"`
+	RemotePort int    `json:"remote_port"`
+	State      string `json:"state"`
+	TxQueue    uint64 `json:"tx_queue"`
+	RxQueue    uint64 `json:"rx_queue"`
+	UID        uint32 `json:"uid"`
+	Inode      uint64 `json:"inode"`
+	Path       string `json:"path,omitempty"`
+	PID        int    `json:"pid"`
+	Process    string `json:"process"`
+}
+
+// GetConnections returns a mock listening socket for Windows
+func GetConnections(proto string) ([]Connection, error) {
+	switch proto {
+	case "tcp", "tcp4", "inet", "all":
+		return []Connection{
+			{Proto: "tcp", LocalAddr: "0.0.0.0", LocalPort: 5001, RemoteAddr: "0.0.0.0", State: "LISTEN", PID: 4, Process: "api.exe"},
+		}, nil
+	case "tcp6", "udp", "udp4", "udp6", "unix":
+		return []Connection{}, nil
+	}
+	return nil, fmt.Errorf("unknown protocol %q", proto)
+}
@@ -0,0 +1,63 @@
+//go:build linux
+// +build linux
+
+package network
+
+import (
+	"bytes"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// ioctl request and ethtool command, see include/uapi/linux/sockios.h and ethtool.h
+const (
+	siocEthtool     = 0x8946
+	ethtoolGDrvInfo = 0x00000003
+)
+
+// ethtoolDrvInfo mirrors struct ethtool_drvinfo
+type ethtoolDrvInfo struct {
+	Cmd         uint32
+	Driver      [32]byte
+	Version     [32]byte
+	FWVersion   [32]byte
+	BusInfo     [32]byte
+	EROMVersion [32]byte
+	Reserved2   [12]byte
+	NPrivFlags  uint32
+	NStats      uint32
+	TestInfoLen uint32
+	EEDumpLen   uint32
+	RegDumpLen  uint32
+}
+
+// ifreqData mirrors struct ifreq using its ifr_data member
+type ifreqData struct {
+	Name [16]byte
+	Data uintptr
+	_    [24 - unsafe.Sizeof(uintptr(0))]byte
+}
+
+// ethtoolDriver returns the driver name the kernel reports for an interface
+// through ETHTOOL_GDRVINFO, or "" if it cannot be queried. Unlike the
+// device/driver link in sysfs, this also works for virtual interfaces.
+func ethtoolDriver(name string) string {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return ""
+	}
+	defer syscall.Close(fd)
+
+	info := &ethtoolDrvInfo{Cmd: ethtoolGDrvInfo}
+	var req ifreqData
+	copy(req.Name[:len(req.Name)-1], name)
+	req.Data = uintptr(unsafe.Pointer(info))
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), siocEthtool, uintptr(unsafe.Pointer(&req)))
+	runtime.KeepAlive(info)
+	if errno != 0 {
+		return ""
+	}
+	return string(bytes.TrimRight(info.Driver[:], "\x00"))
+}
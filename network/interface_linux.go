@@ -0,0 +1,157 @@
+//go:build linux
+// +build linux
+
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Interface represents the link settings of a network interface from /sys/class/net/<if>
+type Interface struct {
+	Name  string `json:"name"`
+	Index int    `json:"index"`
+	// Type is one of ether, loopback, bridge, bond, vlan, veth, tun, wireless,
+	// or other for virtual devices it cannot tell apart (macvlan, vxlan, gre, ...)
+	Type string `json:"type"`
+	// OperState is the RFC 2863 state: up, down, dormant, lowerlayerdown, unknown, ...
+	OperState string `json:"operstate"`
+	Carrier   bool   `json:"carrier"`
+	MTU       int    `json:"mtu"`
+	// SpeedMbps is the negotiated link speed, 0 when unknown (virtual devices, link down)
+	SpeedMbps  int    `json:"speed_mbps"`
+	Duplex     string `json:"duplex"`
+	MAC        string `json:"mac"`
+	Driver     string `json:"driver"`
+	TxQueueLen int    `json:"tx_queue_len"`
+	// Master is the bridge or bond this interface is enslaved to
+	Master string `json:"master"`
+	// Slaves are the ports of a bridge or the members of a bond
+	Slaves []string `json:"slaves"`
+}
+
+const sysClassNetPath = "/sys/class/net"
+
+// GetInterfaces returns the type, state, MTU, speed, driver and master/slave
+// relationships of every network interface, sorted by index
+func GetInterfaces() ([]Interface, error) {
+	entries, err := os.ReadDir(sysClassNetPath)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces := []Interface{}
+	for _, entry := range entries {
+		dir := filepath.Join(sysClassNetPath, entry.Name())
+		// bonding_masters is a control file, not an interface
+		if _, err := os.Stat(filepath.Join(dir, "ifindex")); err != nil {
+			continue
+		}
+
+		iface := Interface{
+			Name:      entry.Name(),
+			OperState: readSysString(filepath.Join(dir, "operstate")),
+			Duplex:    readSysString(filepath.Join(dir, "duplex")),
+			MAC:       readSysString(filepath.Join(dir, "address")),
+			Slaves:    []string{},
+		}
+		iface.Index, _ = strconv.Atoi(readSysString(filepath.Join(dir, "ifindex")))
+		iface.MTU, _ = strconv.Atoi(readSysString(filepath.Join(dir, "mtu")))
+		iface.TxQueueLen, _ = strconv.Atoi(readSysString(filepath.Join(dir, "tx_queue_len")))
+		// carrier and speed fail with EINVAL while the interface is down
+		iface.Carrier = readSysString(filepath.Join(dir, "carrier")) == "1"
+		if speed, err := strconv.Atoi(readSysString(filepath.Join(dir, "speed"))); err == nil && speed > 0 {
+			iface.SpeedMbps = speed
+		}
+
+		if target, err := os.Readlink(filepath.Join(dir, "device/driver")); err == nil {
+			iface.Driver = filepath.Base(target)
+		} else {
+			// Virtual interfaces have no device in sysfs but still name their driver to ethtool
+			iface.Driver = ethtoolDriver(iface.Name)
+		}
+		if target, err := os.Readlink(filepath.Join(dir, "master")); err == nil {
+			iface.Master = filepath.Base(target)
+		}
+
+		iface.Type = interfaceType(dir, &iface)
+		switch iface.Type {
+		case "bridge":
+			if ports, err := os.ReadDir(filepath.Join(dir, "brif")); err == nil {
+				for _, p := range ports {
+					iface.Slaves = append(iface.Slaves, p.Name())
+				}
+			}
+		case "bond":
+			iface.Slaves = append(iface.Slaves, strings.Fields(readSysString(filepath.Join(dir, "bonding/slaves")))...)
+		}
+
+		interfaces = append(interfaces, iface)
+	}
+
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].Index < interfaces[j].Index
+	})
+
+	return interfaces, nil
+}
+
+// interfaceType classifies an interface from its sysfs attributes and driver,
+// returning "other" for anything it cannot identify
+func interfaceType(dir string, iface *Interface) string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+
+	devType := ""
+	for _, line := range strings.Split(readSysString(filepath.Join(dir, "uevent")), "\n") {
+		if v, ok := strings.CutPrefix(line, "DEVTYPE="); ok {
+			devType = v
+		}
+	}
+
+	// ARPHRD_* link type, see include/uapi/linux/if_arp.h
+	arpType := readSysString(filepath.Join(dir, "type"))
+
+	switch {
+	case arpType == "772":
+		return "loopback"
+	case devType == "bridge" || exists("bridge"):
+		return "bridge"
+	case devType == "bond" || exists("bonding"):
+		return "bond"
+	case devType == "vlan":
+		return "vlan"
+	case devType == "wlan" || exists("wireless") || exists("phy80211"):
+		return "wireless"
+	case exists("tun_flags"):
+		return "tun"
+	case devType != "" || arpType != "1":
+		// vxlan, geneve, gretap, macsec, wwan, ipip, sit, ...
+		return "other"
+	case exists("device"):
+		// Ethernet backed by a bus device (PCI, USB, virtio, ...)
+		return "ether"
+	}
+
+	// Virtual Ethernet without a DEVTYPE: veth, macvlan, ipvlan, dummy, ifb, ...
+	// Only the driver tells them apart
+	if iface.Driver == "veth" {
+		return "veth"
+	}
+	return "other"
+}
+
+// readSysString returns the trimmed content of a sysfs attribute, or "" if it cannot be read
+func readSysString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
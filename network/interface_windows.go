@@ -0,0 +1,28 @@
+//go:build windows
+// +build windows
+
+package network
+
+// Interface represents the link settings of a network interface
+type Interface struct {
+	Name       string   `json:"name"`
+	Index      int      `json:"index"`
+	Type       string   `json:"type"`
+	OperState  string   `json:"operstate"`
+	Carrier    bool     `json:"carrier"`
+	MTU        int      `json:"mtu"`
+	SpeedMbps  int      `json:"speed_mbps"`
+	Duplex     string   `json:"duplex"`
+	MAC        string   `json:"mac"`
+	Driver     string   `json:"driver"`
+	TxQueueLen int      `json:"tx_queue_len"`
+	Master     string   `json:"master"`
+	Slaves     []string `json:"slaves"`
+}
+
+// GetInterfaces returns a mock interface for Windows
+func GetInterfaces() ([]Interface, error) {
+	return []Interface{
+		{Name: "Ethernet", Index: 1, Type: "ether", OperState: "up", Carrier: true, MTU: 1500, SpeedMbps: 1000, Duplex: "full", Slaves: []string{}},
+	}, nil
+}
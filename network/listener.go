@@ -0,0 +1,81 @@
+package network
+
+import (
+	"sort"
+
+	"github.com/avirooppal/gosysutil/process"
+)
+
+// Listener represents a TCP socket in LISTEN state or a bound, unconnected UDP socket
+type Listener struct {
+	// Proto is "tcp", "tcp6", "udp" or "udp6"
+	Proto   string `json:"proto"`
+	Address string `json:"address"`
+	Port    int    `json:"port"`
+	// AcceptQueue is the number of connections waiting to be accepted and
+	// Backlog the listen() backlog it cannot exceed, as reported by sock_diag.
+	// Both are zero for UDP.
+	AcceptQueue uint64 `json:"accept_queue"`
+	Backlog     uint64 `json:"backlog"`
+	// RecvQueue is the number of bytes waiting to be read on a UDP socket
+	RecvQueue uint64 `json:"recv_queue"`
+	UID       uint32 `json:"uid"`
+	Inode     uint64 `json:"inode"`
+	// PID, Process and Cmdline identify the owner when it could be resolved
+	PID     int    `json:"pid"`
+	Process string `json:"process"`
+	Cmdline string `json:"cmdline"`
+}
+
+// GetListeners returns every listening TCP socket and bound UDP socket with
+// its accept queue and owning process, sorted by protocol and port
+func GetListeners() ([]Listener, error) {
+	connections, err := GetConnections("inet")
+	if err != nil {
+		return nil, err
+	}
+
+	// sock_diag can be blocked in some sandboxes; Backlog is then left at 0
+	backlogs, _ := listenBacklogs()
+
+	listeners := []Listener{}
+	for _, c := range connections {
+		l := Listener{
+			Proto:   c.Proto,
+			Address: c.LocalAddr,
+			Port:    c.LocalPort,
+			UID:     c.UID,
+			Inode:   c.Inode,
+			PID:     c.PID,
+			Process: c.Process,
+		}
+
+		switch {
+		case c.State == "LISTEN":
+			// For listening TCP sockets the kernel reports the accept queue length in rx_queue
+			l.AcceptQueue = c.RxQueue
+			l.Backlog = backlogs[c.Inode]
+		case c.State == "UNCONN" && c.LocalPort != 0:
+			l.RecvQueue = c.RxQueue
+		default:
+			continue
+		}
+
+		if l.PID > 0 {
+			if p, err := process.GetProcess(l.PID); err == nil {
+				l.Cmdline = p.Cmdline
+			}
+		}
+
+		listeners = append(listeners, l)
+	}
+
+	sort.SliceStable(listeners, func(i, j int) bool {
+		if listeners[i].Proto != listeners[j].Proto {
+			return listeners[i].Proto < listeners[j].Proto
+		}
+		return listeners[i].Port < listeners[j].Port
+	})
+
+	return listeners, nil
+}
@@ -1,3 +1,4 @@
+//go:build linux
 // +build linux
 
 package network
@@ -7,19 +8,30 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // NetworkStats represents network interface statistics from /proc/net/dev
 type NetworkStats struct {
-	Name        string
-	RxBytes     uint64
-	RxPackets   uint64
-	RxErrors    uint64
-	RxDropped   uint64
-	TxBytes     uint64
-	TxPackets   uint64
-	TxErrors    uint64
-	TxDropped   uint64
+	Name         string
+	RxBytes      uint64
+	RxPackets    uint64
+	RxErrors     uint64
+	RxDropped    uint64
+	RxFifo       uint64
+	RxFrame      uint64
+	RxCompressed uint64
+	RxMulticast  uint64
+	TxBytes      uint64
+	TxPackets    uint64
+	TxErrors     uint64
+	TxDropped    uint64
+	TxFifo       uint64
+	TxCollisions uint64
+	TxCarrier    uint64
+	TxCompressed uint64
+	// Timestamp is when the counters were read, for computing rates between samples
+	Timestamp time.Time
 }
 
 // GetNetwork returns network statistics for all interfaces in /proc/net/dev
@@ -30,52 +42,36 @@ func GetNetwork() ([]NetworkStats, error) {
 	}
 	defer file.Close()
 
+	now := time.Now()
 	var netStats []NetworkStats
 	scanner := bufio.NewScanner(file)
-    
-    // Skip first 2 lines (header)
-    if scanner.Scan() { // Line 1
-        if scanner.Scan() { // Line 2
-             // Good, filtered headers
-        }
-    }
-
 	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Split(line, ":")
-        if len(parts) != 2 {
-            continue
-        }
-        
-        name := strings.TrimSpace(parts[0])
-        fields := strings.Fields(parts[1])
-        
-        if len(fields) < 16 {
-            // Need at least up to tx_compressed usually, or just check basic 8 for RX/TX
-            // Standard is 16 fields
-            if len(fields) < 8 {
-                 continue
-            }
-        }
-        
-        stats := NetworkStats{
-            Name: name,
-        }
-        
-        // RX
-        stats.RxBytes, _ = strconv.ParseUint(fields[0], 10, 64)
-        stats.RxPackets, _ = strconv.ParseUint(fields[1], 10, 64)
-        stats.RxErrors, _ = strconv.ParseUint(fields[2], 10, 64)
-        stats.RxDropped, _ = strconv.ParseUint(fields[3], 10, 64)
-        // 4,5,6,7 are fifo, frame, compressed, multicast
-        
-        // TX starts at index 8
-        if len(fields) >= 16 {
-             stats.TxBytes, _ = strconv.ParseUint(fields[8], 10, 64)
-             stats.TxPackets, _ = strconv.ParseUint(fields[9], 10, 64)
-             stats.TxErrors, _ = strconv.ParseUint(fields[10], 10, 64)
-             stats.TxDropped, _ = strconv.ParseUint(fields[11], 10, 64)
-        }
+		// The two header lines have no colon after the interface name
+		name, counters, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		fields := strings.Fields(counters)
+		if len(fields) < 16 {
+			continue
+		}
+
+		stats := NetworkStats{
+			Name:      strings.TrimSpace(name),
+			Timestamp: now,
+		}
+
+		// bytes packets errs drop fifo frame compressed multicast, then the TX set
+		// bytes packets errs drop fifo colls carrier compressed
+		for i, p := range []*uint64{
+			&stats.RxBytes, &stats.RxPackets, &stats.RxErrors, &stats.RxDropped,
+			&stats.RxFifo, &stats.RxFrame, &stats.RxCompressed, &stats.RxMulticast,
+			&stats.TxBytes, &stats.TxPackets, &stats.TxErrors, &stats.TxDropped,
+			&stats.TxFifo, &stats.TxCollisions, &stats.TxCarrier, &stats.TxCompressed,
+		} {
+			*p, _ = strconv.ParseUint(fields[i], 10, 64)
+		}
 
 		netStats = append(netStats, stats)
 	}
@@ -2,22 +2,33 @@
 
 package network
 
+import "time"
+
 // NetworkStats represents network interface statistics
 type NetworkStats struct {
-	Name        string
-	RxBytes     uint64
-	RxPackets   uint64
-	RxErrors    uint64
-	RxDropped   uint64
-	TxBytes     uint64
-	TxPackets   uint64
-	TxErrors    uint64
-	TxDropped   uint64
+	Name         string
+	RxBytes      uint64
+	RxPackets    uint64
+	RxErrors     uint64
+	RxDropped    uint64
+	RxFifo       uint64
+	RxFrame      uint64
+	RxCompressed uint64
+	RxMulticast  uint64
+	TxBytes      uint64
+	TxPackets    uint64
+	TxErrors     uint64
+	TxDropped    uint64
+	TxFifo       uint64
+	TxCollisions uint64
+	TxCarrier    uint64
+	TxCompressed uint64
+	Timestamp    time.Time
 }
 
 // GetNetwork returns mock network statistics for Windows
 func GetNetwork() ([]NetworkStats, error) {
 	return []NetworkStats{
-		{Name: "Ethernet", RxBytes: 1000000, TxBytes: 500000},
+		{Name: "Ethernet", RxBytes: 1000000, TxBytes: 500000, Timestamp: time.Now()},
 	}, nil
 }
@@ -0,0 +1,19 @@
+package network
+
+// DefaultGateway returns the default route with the lowest metric among routes,
+// preferring IPv4, or nil if there is none
+func DefaultGateway(routes []Route) *Route {
+	var best *Route
+	for i := range routes {
+		r := &routes[i]
+		if !r.Default {
+			continue
+		}
+		if best == nil ||
+			(best.Family != "inet" && r.Family == "inet") ||
+			(best.Family == r.Family && r.Metric < best.Metric) {
+			best = r
+		}
+	}
+	return best
+}
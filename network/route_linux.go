@@ -0,0 +1,173 @@
+//go:build linux
+// +build linux
+
+package network
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Route represents an entry of the kernel routing table
+type Route struct {
+	// Family is "inet" or "inet6"
+	Family string `json:"family"`
+	// Destination is in CIDR notation, e.g. "10.0.0.0/8" or "::/0"
+	Destination string `json:"destination"`
+	// Gateway is empty for directly connected routes
+	Gateway   string `json:"gateway"`
+	Interface string `json:"interface"`
+	Metric    uint32 `json:"metric"`
+	// Default is true for routes to 0.0.0.0/0 or ::/0 through a gateway
+	Default bool `json:"default"`
+}
+
+// Route flags from include/uapi/linux/route.h and ipv6_route.h
+const (
+	rtfUp     = 0x0001
+	rtfReject = 0x0200
+	rtfCache  = 0x01000000
+	rtfLocal  = 0x80000000
+)
+
+// GetRoutes returns the IPv4 routes from /proc/net/route followed by the IPv6
+// routes from /proc/net/ipv6_route. Routes that are down, cached, local or
+// reject entries are skipped.
+func GetRoutes() ([]Route, error) {
+	routes, err := readIPv4Routes()
+	if err != nil {
+		return nil, err
+	}
+
+	v6, err := readIPv6Routes()
+	if err != nil && !os.IsNotExist(err) {
+		// ipv6_route is missing when IPv6 is disabled
+		return nil, err
+	}
+
+	return append(routes, v6...), nil
+}
+
+// GetDefaultGateway returns the default route with the lowest metric,
+// preferring IPv4, or nil if there is none. Callers that also need the
+// routes should use GetRoutes and DefaultGateway to read the tables once.
+func GetDefaultGateway() (*Route, error) {
+	routes, err := GetRoutes()
+	if err != nil {
+		return nil, err
+	}
+	return DefaultGateway(routes), nil
+}
+
+func readIPv4Routes() ([]Route, error) {
+	file, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	routes := []Route{}
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // header
+
+	for scanner.Scan() {
+		// Iface Destination Gateway Flags RefCnt Use Metric Mask MTU Window IRTT
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 {
+			continue
+		}
+
+		flags, _ := strconv.ParseUint(fields[3], 16, 32)
+		if flags&rtfUp == 0 || flags&rtfReject != 0 {
+			continue
+		}
+
+		dest, err1 := parseIPv4Hex(fields[1])
+		gateway, err2 := parseIPv4Hex(fields[2])
+		mask, err3 := parseIPv4Hex(fields[7])
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("invalid route in /proc/net/route: %q", scanner.Text())
+		}
+		ones, _ := net.IPMask(mask).Size()
+		metric, _ := strconv.ParseUint(fields[6], 10, 32)
+
+		r := Route{
+			Family:      "inet",
+			Destination: fmt.Sprintf("%s/%d", dest, ones),
+			Interface:   fields[0],
+			Metric:      uint32(metric),
+		}
+		if !gateway.IsUnspecified() {
+			r.Gateway = gateway.String()
+		}
+		r.Default = ones == 0 && r.Gateway != ""
+
+		routes = append(routes, r)
+	}
+
+	return routes, scanner.Err()
+}
+
+func readIPv6Routes() ([]Route, error) {
+	file, err := os.Open("/proc/net/ipv6_route")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	routes := []Route{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// dest dest_prefix src src_prefix next_hop metric refcnt use flags iface
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+
+		flags, _ := strconv.ParseUint(fields[8], 16, 32)
+		if flags&rtfUp == 0 || flags&(rtfReject|rtfCache|rtfLocal) != 0 {
+			continue
+		}
+
+		dest, err1 := hex.DecodeString(fields[0])
+		nextHop, err2 := hex.DecodeString(fields[4])
+		prefix, err3 := strconv.ParseUint(fields[1], 16, 8)
+		if err1 != nil || err2 != nil || err3 != nil || len(dest) != net.IPv6len || len(nextHop) != net.IPv6len {
+			return nil, fmt.Errorf("invalid route in /proc/net/ipv6_route: %q", scanner.Text())
+		}
+		metric, _ := strconv.ParseUint(fields[5], 16, 32)
+
+		r := Route{
+			Family:      "inet6",
+			Destination: fmt.Sprintf("%s/%d", net.IP(dest), prefix),
+			Interface:   fields[9],
+			Metric:      uint32(metric),
+		}
+		if gw := net.IP(nextHop); !gw.IsUnspecified() {
+			r.Gateway = gw.String()
+		}
+		r.Default = prefix == 0 && r.Gateway != ""
+
+		routes = append(routes, r)
+	}
+
+	return routes, scanner.Err()
+}
+
+// parseIPv4Hex decodes an address from /proc/net/route, which the kernel prints
+// as a 32-bit hex number in host byte order
+func parseIPv4Hex(s string) (net.IP, error) {
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, err
+	}
+	ip := make(net.IP, net.IPv4len)
+	binary.NativeEndian.PutUint32(ip, uint32(v))
+	return ip, nil
+}
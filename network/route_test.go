@@ -0,0 +1,58 @@
+package network
+
+import "testing"
+
+func TestDefaultGateway(t *testing.T) {
+	tests := []struct {
+		name   string
+		routes []Route
+		want   string
+	}{
+		{
+			name: "no default route",
+			routes: []Route{
+				{Family: "inet", Destination: "10.0.0.0/8", Interface: "eth0"},
+			},
+			want: "",
+		},
+		{
+			name: "lowest metric wins",
+			routes: []Route{
+				{Family: "inet", Destination: "0.0.0.0/0", Gateway: "10.0.0.1", Metric: 600, Default: true},
+				{Family: "inet", Destination: "0.0.0.0/0", Gateway: "192.168.1.1", Metric: 100, Default: true},
+			},
+			want: "192.168.1.1",
+		},
+		{
+			name: "ipv4 preferred over a lower ipv6 metric",
+			routes: []Route{
+				{Family: "inet6", Destination: "::/0", Gateway: "fe80::1", Metric: 1, Default: true},
+				{Family: "inet", Destination: "0.0.0.0/0", Gateway: "10.0.0.1", Metric: 600, Default: true},
+			},
+			want: "10.0.0.1",
+		},
+		{
+			name: "ipv6 only",
+			routes: []Route{
+				{Family: "inet6", Destination: "::/0", Gateway: "fe80::2", Metric: 1024, Default: true},
+				{Family: "inet6", Destination: "::/0", Gateway: "fe80::1", Metric: 100, Default: true},
+			},
+			want: "fe80::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultGateway(tt.routes)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("DefaultGateway() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Gateway != tt.want {
+				t.Errorf("DefaultGateway() = %+v, want gateway %s", got, tt.want)
+			}
+		})
+	}
+}
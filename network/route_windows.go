@@ -0,0 +1,27 @@
+//go:build windows
+// +build windows
+
+package network
+
+// Route represents an entry of the routing table
+type Route struct {
+	Family      string `json:"family"`
+	Destination string `json:"destination"`
+	Gateway     string `json:"gateway"`
+	Interface   string `json:"interface"`
+	Metric      uint32 `json:"metric"`
+	Default     bool   `json:"default"`
+}
+
+// GetRoutes returns a mock default route for Windows
+func GetRoutes() ([]Route, error) {
+	return []Route{
+		{Family: "inet", Destination: "0.0.0.0/0", Gateway: "192.168.1.1", Interface: "Ethernet", Default: true},
+	}, nil
+}
+
+// GetDefaultGateway returns the mock default route for Windows
+func GetDefaultGateway() (*Route, error) {
+	routes, _ := GetRoutes()
+	return DefaultGateway(routes), nil
+}
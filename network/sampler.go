@@ -0,0 +1,42 @@
+package network
+
+import (
+	"time"
+
+	"github.com/avirooppal/gosysutil/sampler"
+)
+
+// Sampler reads interface statistics in the background on a fixed interval and
+// serves usage computed from the last two samples, so callers never block
+// waiting for a measurement window.
+type Sampler struct {
+	s *sampler.Sampler[[]NetworkStats, []Usage]
+}
+
+// NewSampler returns a Sampler that reads /proc/net/dev every interval.
+// Call Start to begin sampling.
+func NewSampler(interval time.Duration) *Sampler {
+	return &Sampler{s: sampler.New(interval, GetNetwork, CalculateUsage)}
+}
+
+// Start takes an initial sample and begins sampling in the background.
+// Usage is available once the first interval has elapsed.
+// Calling Start more than once has no effect.
+func (s *Sampler) Start() error {
+	return s.s.Start()
+}
+
+// Stop ends background sampling. The last computed usage remains available.
+func (s *Sampler) Stop() {
+	s.s.Stop()
+}
+
+// Usage returns the usage of every interface over the last interval, or
+// sampler.ErrNotReady until the second sample has been taken
+func (s *Sampler) Usage() ([]Usage, error) {
+	usage, err := s.s.Value()
+	if err != nil {
+		return nil, err
+	}
+	return append([]Usage{}, usage...), nil
+}
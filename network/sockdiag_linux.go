@@ -0,0 +1,91 @@
+//go:build linux
+// +build linux
+
+package network
+
+import (
+	"encoding/binary"
+	"fmt"
+	"syscall"
+)
+
+// Netlink constants, see include/uapi/linux/sock_diag.h and inet_diag.h
+const (
+	netlinkSockDiag   = 4
+	sockDiagByFamily  = 20
+	tcpListenState    = 10
+	inetDiagReqV2Len  = 56
+	inetDiagMsgLen    = 72
+	inetDiagWQueueOff = 60
+	inetDiagInodeOff  = 68
+)
+
+// listenBacklogs returns the listen() backlog of every listening TCP socket,
+// keyed by inode. /proc/net/tcp does not expose it, but sock_diag reports it
+// in idiag_wqueue for sockets in LISTEN state.
+func listenBacklogs() (map[uint64]uint64, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, netlinkSockDiag)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(fd)
+
+	backlogs := make(map[uint64]uint64)
+	for _, family := range []uint8{syscall.AF_INET, syscall.AF_INET6} {
+		if err := dumpListenSockets(fd, family, backlogs); err != nil {
+			return nil, err
+		}
+	}
+	return backlogs, nil
+}
+
+// dumpListenSockets sends an inet_diag_req_v2 dump request for the listening
+// TCP sockets of one address family and records each backlog in backlogs
+func dumpListenSockets(fd int, family uint8, backlogs map[uint64]uint64) error {
+	req := make([]byte, syscall.NLMSG_HDRLEN+inetDiagReqV2Len)
+	// struct nlmsghdr
+	binary.NativeEndian.PutUint32(req[0:], uint32(len(req)))
+	binary.NativeEndian.PutUint16(req[4:], sockDiagByFamily)
+	binary.NativeEndian.PutUint16(req[6:], syscall.NLM_F_REQUEST|syscall.NLM_F_DUMP)
+	binary.NativeEndian.PutUint32(req[8:], 1)
+	// struct inet_diag_req_v2: family, protocol, ext, pad, states, then a zero sockid
+	req[16] = family
+	req[17] = syscall.IPPROTO_TCP
+	binary.NativeEndian.PutUint32(req[20:], 1<<tcpListenState)
+
+	if err := syscall.Sendto(fd, req, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, _, err := syscall.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return err
+		}
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return err
+		}
+
+		for _, m := range msgs {
+			switch m.Header.Type {
+			case syscall.NLMSG_DONE:
+				return nil
+			case syscall.NLMSG_ERROR:
+				if len(m.Data) >= 4 {
+					if errno := int32(binary.NativeEndian.Uint32(m.Data)); errno != 0 {
+						return fmt.Errorf("sock_diag: %w", syscall.Errno(-errno))
+					}
+				}
+				return nil
+			case sockDiagByFamily:
+				if len(m.Data) < inetDiagMsgLen {
+					continue
+				}
+				inode := binary.NativeEndian.Uint32(m.Data[inetDiagInodeOff:])
+				backlogs[uint64(inode)] = uint64(binary.NativeEndian.Uint32(m.Data[inetDiagWQueueOff:]))
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+//go:build linux
+// +build linux
+
+package network
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestListenBacklogs(t *testing.T) {
+	// net.Listen always asks for somaxconn, so listen with a known backlog directly
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		t.Skipf("socket: %v", err)
+	}
+	defer syscall.Close(fd)
+	if err := syscall.Bind(fd, &syscall.SockaddrInet4{Addr: [4]byte{127, 0, 0, 1}}); err != nil {
+		t.Skipf("bind: %v", err)
+	}
+	if err := syscall.Listen(fd, 7); err != nil {
+		t.Skipf("listen: %v", err)
+	}
+
+	var st syscall.Stat_t
+	if err := syscall.Fstat(fd, &st); err != nil {
+		t.Fatalf("fstat: %v", err)
+	}
+
+	backlogs, err := listenBacklogs()
+	if err != nil {
+		t.Skipf("sock_diag unavailable: %v", err)
+	}
+	backlog, ok := backlogs[st.Ino]
+	if !ok {
+		t.Fatalf("socket inode %d not reported by sock_diag", st.Ino)
+	}
+	if backlog != 7 {
+		t.Errorf("backlog = %d, want 7", backlog)
+	}
+}
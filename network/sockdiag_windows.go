@@ -0,0 +1,9 @@
+//go:build windows
+// +build windows
+
+package network
+
+// listenBacklogs returns a mock backlog for the mock listener on Windows
+func listenBacklogs() (map[uint64]uint64, error) {
+	return map[uint64]uint64{0: 200}, nil
+}
@@ -0,0 +1,78 @@
+package network
+
+// Usage represents per-second traffic of one interface between two samples
+type Usage struct {
+	Name              string  `json:"name"`
+	RxBytesPerSec     float64 `json:"rx_bytes_per_sec"`
+	TxBytesPerSec     float64 `json:"tx_bytes_per_sec"`
+	RxPacketsPerSec   float64 `json:"rx_packets_per_sec"`
+	TxPacketsPerSec   float64 `json:"tx_packets_per_sec"`
+	RxErrorsPerSec    float64 `json:"rx_errors_per_sec"`
+	TxErrorsPerSec    float64 `json:"tx_errors_per_sec"`
+	RxDroppedPerSec   float64 `json:"rx_dropped_per_sec"`
+	TxDroppedPerSec   float64 `json:"tx_dropped_per_sec"`
+	RxMulticastPerSec float64 `json:"rx_multicast_per_sec"`
+}
+
+// CalculateUsage computes the usage of every interface present in both samples,
+// using the time elapsed between their timestamps
+func CalculateUsage(prev, cur []NetworkStats) []Usage {
+	last := make(map[string]*NetworkStats, len(prev))
+	for i := range prev {
+		last[prev[i].Name] = &prev[i]
+	}
+
+	var usage []Usage
+	for i := range cur {
+		p, ok := last[cur[i].Name]
+		if !ok {
+			continue
+		}
+		if u := calculateUsage(p, &cur[i]); u != nil {
+			usage = append(usage, *u)
+		}
+	}
+	return usage
+}
+
+// calculateUsage returns nil when the samples are not in chronological order
+func calculateUsage(prev, cur *NetworkStats) *Usage {
+	seconds := cur.Timestamp.Sub(prev.Timestamp).Seconds()
+	if seconds <= 0 {
+		return nil
+	}
+
+	rate := func(p, c uint64) float64 {
+		// Counters go backwards when an interface is recreated
+		if c < p {
+			return 0
+		}
+		return float64(c-p) / seconds
+	}
+
+	return &Usage{
+		Name:              cur.Name,
+		RxBytesPerSec:     rate(prev.RxBytes, cur.RxBytes),
+		TxBytesPerSec:     rate(prev.TxBytes, cur.TxBytes),
+		RxPacketsPerSec:   rate(prev.RxPackets, cur.RxPackets),
+		TxPacketsPerSec:   rate(prev.TxPackets, cur.TxPackets),
+		RxErrorsPerSec:    rate(prev.RxErrors, cur.RxErrors),
+		TxErrorsPerSec:    rate(prev.TxErrors, cur.TxErrors),
+		RxDroppedPerSec:   rate(prev.RxDropped, cur.RxDropped),
+		TxDroppedPerSec:   rate(prev.TxDropped, cur.TxDropped),
+		RxMulticastPerSec: rate(prev.RxMulticast, cur.RxMulticast),
+	}
+}
+
+// Utilization returns the busier direction of u as a percentage of the link
+// speed of iface, or 0 when the speed is unknown
+func (iface *Interface) Utilization(u *Usage) float64 {
+	if iface.SpeedMbps <= 0 {
+		return 0
+	}
+	bytesPerSec := u.RxBytesPerSec
+	if u.TxBytesPerSec > bytesPerSec {
+		bytesPerSec = u.TxBytesPerSec
+	}
+	return bytesPerSec * 8 / (float64(iface.SpeedMbps) * 1e6) * 100
+}
@@ -55,6 +55,11 @@ func GetProcesses() ([]Process, error) {
 	return processes, nil
 }
 
+// GetProcess returns the process with the given PID
+func GetProcess(pid int) (*Process, error) {
+	return parseProcess(strconv.Itoa(pid))
+}
+
 func parseProcess(pidStr string) (*Process, error) {
 	pid, _ := strconv.Atoi(pidStr)
 	statPath := filepath.Join("/proc", pidStr, "stat")
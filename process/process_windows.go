@@ -2,6 +2,8 @@
 
 package process
 
+import "fmt"
+
 // Process represents a single process
 type Process struct {
 	PID     int
@@ -23,6 +25,17 @@ func GetProcesses() ([]Process, error) {
 	}, nil
 }
 
+// GetProcess returns the mock process with the given PID for Windows
+func GetProcess(pid int) (*Process, error) {
+	procs, _ := GetProcesses()
+	for i := range procs {
+		if procs[i].PID == pid {
+			return &procs[i], nil
+		}
+	}
+	return nil, fmt.Errorf("process %d not found", pid)
+}
+
 // GetTopByCPU returns mock top N processes by CPU usage for Windows
 func GetTopByCPU(n int) ([]Process, error) {
 	procs, _ := GetProcesses()
@@ -0,0 +1,113 @@
+package sampler
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNotReady is returned until the sampler has taken two samples
+var ErrNotReady = errors.New("sampler is waiting for its second sample")
+
+// Sampler reads cumulative counters in the background on a fixed interval
+// and serves the value computed from the last two samples, so callers never
+// block waiting for a measurement window.
+type Sampler[S, V any] struct {
+	interval time.Duration
+	read     func() (S, error)
+	compute  func(prev, cur S) V
+
+	mu      sync.RWMutex
+	prev    S
+	hasPrev bool
+	value   V
+	ready   bool
+	err     error
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+}
+
+// New returns a Sampler that calls read every interval and compute on each
+// pair of consecutive samples. Call Start to begin sampling.
+func New[S, V any](interval time.Duration, read func() (S, error), compute func(prev, cur S) V) *Sampler[S, V] {
+	return &Sampler[S, V]{
+		interval: interval,
+		read:     read,
+		compute:  compute,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start takes an initial sample and begins sampling in the background.
+// A value is available once the first interval has elapsed.
+// Calling Start more than once has no effect.
+func (s *Sampler[S, V]) Start() error {
+	var err error
+	s.startOnce.Do(func() {
+		err = s.sample()
+		go s.run()
+	})
+	return err
+}
+
+// Stop ends background sampling. The last computed value remains available.
+func (s *Sampler[S, V]) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+}
+
+// Value returns the value computed over the last interval. It returns
+// ErrNotReady before the second sample, or the error of the last read if
+// no value has been computed yet. The value is shared between callers and
+// must not be modified.
+func (s *Sampler[S, V]) Value() (V, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.ready {
+		var zero V
+		if s.err != nil {
+			return zero, s.err
+		}
+		return zero, ErrNotReady
+	}
+	return s.value, nil
+}
+
+func (s *Sampler[S, V]) run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.sample()
+		}
+	}
+}
+
+// sample reads the counters and computes the value against the previous sample
+func (s *Sampler[S, V]) sample() error {
+	cur, err := s.read()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+	if err != nil {
+		return err
+	}
+
+	if s.hasPrev {
+		s.value = s.compute(s.prev, cur)
+		s.ready = true
+	}
+	s.prev, s.hasPrev = cur, true
+
+	return nil
+}
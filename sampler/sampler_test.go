@@ -0,0 +1,67 @@
+package sampler
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSampler(t *testing.T) {
+	counter := 0
+	var readErr error
+	s := New(time.Hour,
+		func() (int, error) {
+			counter += 10
+			return counter, readErr
+		},
+		func(prev, cur int) int {
+			return cur - prev
+		},
+	)
+
+	if _, err := s.Value(); !errors.Is(err, ErrNotReady) {
+		t.Fatalf("before Start: err = %v, want ErrNotReady", err)
+	}
+
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer s.Stop()
+
+	if _, err := s.Value(); !errors.Is(err, ErrNotReady) {
+		t.Fatalf("after one sample: err = %v, want ErrNotReady", err)
+	}
+
+	if err := s.sample(); err != nil {
+		t.Fatalf("sample: %v", err)
+	}
+	if v, err := s.Value(); err != nil || v != 10 {
+		t.Fatalf("after two samples: Value() = %d, %v, want 10, nil", v, err)
+	}
+
+	// A failed read keeps serving the last computed value
+	readErr = errors.New("read failed")
+	if err := s.sample(); err == nil {
+		t.Fatalf("sample: expected the read error")
+	}
+	if v, err := s.Value(); err != nil || v != 10 {
+		t.Fatalf("after a failed read: Value() = %d, %v, want 10, nil", v, err)
+	}
+}
+
+func TestSamplerReadError(t *testing.T) {
+	readErr := errors.New("read failed")
+	s := New(time.Hour,
+		func() (int, error) { return 0, readErr },
+		func(prev, cur int) int { return cur - prev },
+	)
+
+	if err := s.Start(); !errors.Is(err, readErr) {
+		t.Fatalf("Start: err = %v, want %v", err, readErr)
+	}
+	defer s.Stop()
+
+	if _, err := s.Value(); !errors.Is(err, readErr) {
+		t.Fatalf("Value: err = %v, want %v", err, readErr)
+	}
+}
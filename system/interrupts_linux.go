@@ -0,0 +1,186 @@
+//go:build linux
+// +build linux
+
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interrupt represents a single row of /proc/interrupts
+type Interrupt struct {
+	IRQ    string   `json:"irq"`
+	PerCPU []uint64 `json:"per_cpu"`
+	Total  uint64   `json:"total"`
+	// Chip and HWIRQ are only set for numbered IRQs, e.g. "PCI-MSIX-0000:00:04.0" and "1-edge".
+	// Trigger is the separate Level/Edge column some architectures print, such as arm64 GIC rows.
+	Chip        string   `json:"chip,omitempty"`
+	HWIRQ       string   `json:"hwirq,omitempty"`
+	Trigger     string   `json:"trigger,omitempty"`
+	Devices     []string `json:"devices,omitempty"`
+	Description string   `json:"description"`
+}
+
+// InterruptStats represents per-IRQ, per-CPU interrupt counts from /proc/interrupts
+type InterruptStats struct {
+	Timestamp  time.Time   `json:"timestamp"`
+	CPUs       []int       `json:"cpus"`
+	Interrupts []Interrupt `json:"interrupts"`
+}
+
+// SoftIRQ represents a single softirq type from /proc/softirqs
+type SoftIRQ struct {
+	Name   string   `json:"name"`
+	PerCPU []uint64 `json:"per_cpu"`
+	Total  uint64   `json:"total"`
+}
+
+// SoftIRQStats represents per-CPU softirq counts from /proc/softirqs
+type SoftIRQStats struct {
+	Timestamp time.Time `json:"timestamp"`
+	CPUs      []int     `json:"cpus"`
+	SoftIRQs  []SoftIRQ `json:"softirqs"`
+}
+
+// GetInterrupts returns per-IRQ, per-CPU interrupt counts from /proc/interrupts
+func GetInterrupts() (*InterruptStats, error) {
+	file, err := os.Open("/proc/interrupts")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stats, err := parseInterrupts(file)
+	if err != nil {
+		return nil, err
+	}
+	stats.Timestamp = time.Now()
+	return stats, nil
+}
+
+// parseInterrupts parses /proc/interrupts content. Numbered rows read
+// "<chip> <hwirq>[-<handler>] [Level|Edge] <devices>"; the trigger column
+// is only printed on some architectures.
+func parseInterrupts(r io.Reader) (*InterruptStats, error) {
+	stats := &InterruptStats{}
+	scanner := bufio.NewScanner(r)
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read /proc/interrupts")
+	}
+	cpus, err := parseCPUHeader(scanner.Text())
+	if err != nil {
+		return nil, err
+	}
+	stats.CPUs = cpus
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		irq := Interrupt{IRQ: strings.TrimSuffix(fields[0], ":")}
+		irq.PerCPU, irq.Total = parseCounters(fields[1:], len(stats.CPUs))
+
+		rest := fields[1+len(irq.PerCPU):]
+		irq.Description = strings.Join(rest, " ")
+		if _, err := strconv.Atoi(irq.IRQ); err == nil && len(rest) >= 3 {
+			irq.Chip = rest[0]
+			irq.HWIRQ = rest[1]
+			rest = rest[2:]
+			if rest[0] == "Level" || rest[0] == "Edge" {
+				irq.Trigger = rest[0]
+				rest = rest[1:]
+			}
+			if len(rest) > 0 {
+				for _, dev := range strings.Split(strings.Join(rest, " "), ",") {
+					irq.Devices = append(irq.Devices, strings.TrimSpace(dev))
+				}
+			}
+		}
+
+		stats.Interrupts = append(stats.Interrupts, irq)
+	}
+
+	return stats, scanner.Err()
+}
+
+// GetSoftIRQs returns per-CPU softirq counts (NET_RX, NET_TX, TIMER, ...) from /proc/softirqs
+func GetSoftIRQs() (*SoftIRQStats, error) {
+	file, err := os.Open("/proc/softirqs")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stats, err := parseSoftIRQs(file)
+	if err != nil {
+		return nil, err
+	}
+	stats.Timestamp = time.Now()
+	return stats, nil
+}
+
+// parseSoftIRQs parses /proc/softirqs content
+func parseSoftIRQs(r io.Reader) (*SoftIRQStats, error) {
+	stats := &SoftIRQStats{}
+	scanner := bufio.NewScanner(r)
+
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read /proc/softirqs")
+	}
+	cpus, err := parseCPUHeader(scanner.Text())
+	if err != nil {
+		return nil, err
+	}
+	stats.CPUs = cpus
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		softirq := SoftIRQ{Name: strings.TrimSuffix(fields[0], ":")}
+		softirq.PerCPU, softirq.Total = parseCounters(fields[1:], len(stats.CPUs))
+		stats.SoftIRQs = append(stats.SoftIRQs, softirq)
+	}
+
+	return stats, scanner.Err()
+}
+
+// parseCPUHeader parses the "CPU0 CPU1 ..." header line; offline CPUs are left out by the kernel
+func parseCPUHeader(line string) ([]int, error) {
+	var cpus []int
+	for _, field := range strings.Fields(line) {
+		cpu, err := strconv.Atoi(strings.TrimPrefix(field, "CPU"))
+		if err != nil {
+			return nil, fmt.Errorf("unexpected cpu header %q", field)
+		}
+		cpus = append(cpus, cpu)
+	}
+	return cpus, nil
+}
+
+// parseCounters reads up to n leading numeric fields. Rows such as ERR and MIS
+// carry a single system-wide count instead of one per CPU.
+func parseCounters(fields []string, n int) ([]uint64, uint64) {
+	var counters []uint64
+	var total uint64
+	for i := 0; i < len(fields) && i < n; i++ {
+		val, err := strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			break
+		}
+		counters = append(counters, val)
+		total += val
+	}
+	return counters, total
+}
@@ -0,0 +1,131 @@
+//go:build linux
+// +build linux
+
+package system
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseInterrupts(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantCPUs []int
+		want     []Interrupt
+	}{
+		{
+			name: "x86",
+			input: `           CPU0       CPU1
+  0:         44          0   IO-APIC   2-edge      timer
+  9:          0          3   IO-APIC   9-fasteoi   acpi
+ 24:     123456        789   PCI-MSI 524288-edge      nvme0q0, nvme0q1
+NMI:          5          3   Non-maskable interrupts
+LOC:    1234567    2345678   Local timer interrupts
+ERR:          0
+MIS:          0
+`,
+			wantCPUs: []int{0, 1},
+			want: []Interrupt{
+				{IRQ: "0", PerCPU: []uint64{44, 0}, Total: 44, Chip: "IO-APIC", HWIRQ: "2-edge", Devices: []string{"timer"}, Description: "IO-APIC 2-edge timer"},
+				{IRQ: "9", PerCPU: []uint64{0, 3}, Total: 3, Chip: "IO-APIC", HWIRQ: "9-fasteoi", Devices: []string{"acpi"}, Description: "IO-APIC 9-fasteoi acpi"},
+				{IRQ: "24", PerCPU: []uint64{123456, 789}, Total: 124245, Chip: "PCI-MSI", HWIRQ: "524288-edge", Devices: []string{"nvme0q0", "nvme0q1"}, Description: "PCI-MSI 524288-edge nvme0q0, nvme0q1"},
+				{IRQ: "NMI", PerCPU: []uint64{5, 3}, Total: 8, Description: "Non-maskable interrupts"},
+				{IRQ: "LOC", PerCPU: []uint64{1234567, 2345678}, Total: 3580245, Description: "Local timer interrupts"},
+				// ERR and MIS carry one system-wide count
+				{IRQ: "ERR", PerCPU: []uint64{0}},
+				{IRQ: "MIS", PerCPU: []uint64{0}},
+			},
+		},
+		{
+			// arm64 prints the trigger type in its own column after the hwirq
+			name: "arm64 gic",
+			input: `           CPU0       CPU1       CPU2       CPU3
+ 11:      25876      21904      23004      22560     GICv3  27 Level     arch_timer
+ 14:          0          0          0          0     GICv3  23 Level
+ 40:          7          0          0          0   ITS-MSI 16384 Edge      virtio0-config
+IPI0:      1811       2002       1967       1910       Rescheduling interrupts
+IPI1:       100        120        130        110       Function call interrupts
+Err:          0
+`,
+			wantCPUs: []int{0, 1, 2, 3},
+			want: []Interrupt{
+				{IRQ: "11", PerCPU: []uint64{25876, 21904, 23004, 22560}, Total: 93344, Chip: "GICv3", HWIRQ: "27", Trigger: "Level", Devices: []string{"arch_timer"}, Description: "GICv3 27 Level arch_timer"},
+				{IRQ: "14", PerCPU: []uint64{0, 0, 0, 0}, Chip: "GICv3", HWIRQ: "23", Trigger: "Level", Description: "GICv3 23 Level"},
+				{IRQ: "40", PerCPU: []uint64{7, 0, 0, 0}, Total: 7, Chip: "ITS-MSI", HWIRQ: "16384", Trigger: "Edge", Devices: []string{"virtio0-config"}, Description: "ITS-MSI 16384 Edge virtio0-config"},
+				{IRQ: "IPI0", PerCPU: []uint64{1811, 2002, 1967, 1910}, Total: 7690, Description: "Rescheduling interrupts"},
+				{IRQ: "IPI1", PerCPU: []uint64{100, 120, 130, 110}, Total: 460, Description: "Function call interrupts"},
+				{IRQ: "Err", PerCPU: []uint64{0}},
+			},
+		},
+		{
+			// Offline CPUs are missing from the header
+			name: "offline cpu",
+			input: `           CPU0       CPU2
+  1:          3          4   IO-APIC   1-edge      i8042
+`,
+			wantCPUs: []int{0, 2},
+			want: []Interrupt{
+				{IRQ: "1", PerCPU: []uint64{3, 4}, Total: 7, Chip: "IO-APIC", HWIRQ: "1-edge", Devices: []string{"i8042"}, Description: "IO-APIC 1-edge i8042"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := parseInterrupts(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(stats.CPUs, tt.wantCPUs) {
+				t.Errorf("CPUs = %v, want %v", stats.CPUs, tt.wantCPUs)
+			}
+			if len(stats.Interrupts) != len(tt.want) {
+				t.Fatalf("got %d interrupts, want %d", len(stats.Interrupts), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got := stats.Interrupts[i]; !reflect.DeepEqual(got, want) {
+					t.Errorf("interrupt %s:\ngot  %+v\nwant %+v", want.IRQ, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseInterruptsBadHeader(t *testing.T) {
+	for _, input := range []string{"", "CPU0 CPUX\n"} {
+		if _, err := parseInterrupts(strings.NewReader(input)); err == nil {
+			t.Errorf("parseInterrupts(%q) want an error", input)
+		}
+	}
+}
+
+func TestParseSoftIRQs(t *testing.T) {
+	input := `                    CPU0       CPU2
+          HI:          1          0
+       TIMER:     123456     234567
+      NET_TX:         12          0
+      NET_RX:        300        400
+         RCU:      98765      87654
+`
+	stats, err := parseSoftIRQs(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []int{0, 2}; !reflect.DeepEqual(stats.CPUs, want) {
+		t.Errorf("CPUs = %v, want %v", stats.CPUs, want)
+	}
+	want := []SoftIRQ{
+		{Name: "HI", PerCPU: []uint64{1, 0}, Total: 1},
+		{Name: "TIMER", PerCPU: []uint64{123456, 234567}, Total: 358023},
+		{Name: "NET_TX", PerCPU: []uint64{12, 0}, Total: 12},
+		{Name: "NET_RX", PerCPU: []uint64{300, 400}, Total: 700},
+		{Name: "RCU", PerCPU: []uint64{98765, 87654}, Total: 186419},
+	}
+	if !reflect.DeepEqual(stats.SoftIRQs, want) {
+		t.Errorf("got  %+v\nwant %+v", stats.SoftIRQs, want)
+	}
+}
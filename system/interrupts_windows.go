@@ -0,0 +1,61 @@
+//go:build windows
+// +build windows
+
+package system
+
+import "time"
+
+// Interrupt represents a single interrupt source
+type Interrupt struct {
+	IRQ         string   `json:"irq"`
+	PerCPU      []uint64 `json:"per_cpu"`
+	Total       uint64   `json:"total"`
+	Chip        string   `json:"chip,omitempty"`
+	HWIRQ       string   `json:"hwirq,omitempty"`
+	Trigger     string   `json:"trigger,omitempty"`
+	Devices     []string `json:"devices,omitempty"`
+	Description string   `json:"description"`
+}
+
+// InterruptStats represents per-IRQ, per-CPU interrupt counts
+type InterruptStats struct {
+	Timestamp  time.Time   `json:"timestamp"`
+	CPUs       []int       `json:"cpus"`
+	Interrupts []Interrupt `json:"interrupts"`
+}
+
+// SoftIRQ represents a single softirq type
+type SoftIRQ struct {
+	Name   string   `json:"name"`
+	PerCPU []uint64 `json:"per_cpu"`
+	Total  uint64   `json:"total"`
+}
+
+// SoftIRQStats represents per-CPU softirq counts
+type SoftIRQStats struct {
+	Timestamp time.Time `json:"timestamp"`
+	CPUs      []int     `json:"cpus"`
+	SoftIRQs  []SoftIRQ `json:"softirqs"`
+}
+
+// GetInterrupts returns mock interrupt statistics for Windows
+func GetInterrupts() (*InterruptStats, error) {
+	return &InterruptStats{
+		Timestamp: time.Now(),
+		CPUs:      []int{0, 1},
+		Interrupts: []Interrupt{
+			{IRQ: "0", PerCPU: []uint64{100, 50}, Total: 150, Description: "timer"},
+		},
+	}, nil
+}
+
+// GetSoftIRQs returns mock softirq statistics for Windows
+func GetSoftIRQs() (*SoftIRQStats, error) {
+	return &SoftIRQStats{
+		Timestamp: time.Now(),
+		CPUs:      []int{0, 1},
+		SoftIRQs: []SoftIRQ{
+			{Name: "TIMER", PerCPU: []uint64{1000, 900}, Total: 1900},
+		},
+	}, nil
+}
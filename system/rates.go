@@ -0,0 +1,112 @@
+package system
+
+// IRQRate represents per-second interrupt or softirq rates between two samples
+type IRQRate struct {
+	Name string `json:"name"`
+	// CPUs are the CPU numbers present in both samples; PerCPU is aligned with it
+	CPUs   []int     `json:"cpus"`
+	PerCPU []float64 `json:"per_cpu"`
+	Total  float64   `json:"total"`
+}
+
+// KernelActivityRate represents per-second kernel activity between two samples
+type KernelActivityRate struct {
+	ContextSwitchesPerSec float64 `json:"context_switches_per_sec"`
+	InterruptsPerSec      float64 `json:"interrupts_per_sec"`
+	ForksPerSec           float64 `json:"forks_per_sec"`
+}
+
+// KernelActivityRates computes per-second context switch, interrupt and fork rates
+func KernelActivityRates(prev, cur *KernelActivity) *KernelActivityRate {
+	seconds := cur.Timestamp.Sub(prev.Timestamp).Seconds()
+	if seconds <= 0 {
+		return &KernelActivityRate{}
+	}
+
+	rate := func(p, c uint64) float64 {
+		if c < p {
+			return 0
+		}
+		return float64(c-p) / seconds
+	}
+
+	return &KernelActivityRate{
+		ContextSwitchesPerSec: rate(prev.ContextSwitches, cur.ContextSwitches),
+		InterruptsPerSec:      rate(prev.Interrupts, cur.Interrupts),
+		ForksPerSec:           rate(prev.Forks, cur.Forks),
+	}
+}
+
+// InterruptRates computes per-second rates for every IRQ present in both samples
+func InterruptRates(prev, cur *InterruptStats) []IRQRate {
+	seconds := cur.Timestamp.Sub(prev.Timestamp).Seconds()
+	if seconds <= 0 {
+		return nil
+	}
+
+	last := make(map[string]*Interrupt, len(prev.Interrupts))
+	for i := range prev.Interrupts {
+		last[prev.Interrupts[i].IRQ] = &prev.Interrupts[i]
+	}
+
+	var rates []IRQRate
+	for _, irq := range cur.Interrupts {
+		p, ok := last[irq.IRQ]
+		if !ok {
+			continue
+		}
+		rates = append(rates, counterRate(irq.IRQ, prev.CPUs, p.PerCPU, cur.CPUs, irq.PerCPU, seconds))
+	}
+	return rates
+}
+
+// SoftIRQRates computes per-second rates for every softirq present in both samples
+func SoftIRQRates(prev, cur *SoftIRQStats) []IRQRate {
+	seconds := cur.Timestamp.Sub(prev.Timestamp).Seconds()
+	if seconds <= 0 {
+		return nil
+	}
+
+	last := make(map[string]*SoftIRQ, len(prev.SoftIRQs))
+	for i := range prev.SoftIRQs {
+		last[prev.SoftIRQs[i].Name] = &prev.SoftIRQs[i]
+	}
+
+	var rates []IRQRate
+	for _, softirq := range cur.SoftIRQs {
+		p, ok := last[softirq.Name]
+		if !ok {
+			continue
+		}
+		rates = append(rates, counterRate(softirq.Name, prev.CPUs, p.PerCPU, cur.CPUs, softirq.PerCPU, seconds))
+	}
+	return rates
+}
+
+// counterRate converts two rows of per-CPU counters into per-second rates.
+// Counters are paired by the CPU numbers of each sample's header, so CPUs
+// hotplugged between the samples are left out rather than misattributed.
+func counterRate(name string, prevCPUs []int, prev []uint64, curCPUs []int, cur []uint64, seconds float64) IRQRate {
+	column := make(map[int]int, len(prevCPUs))
+	for i, cpu := range prevCPUs {
+		if i < len(prev) {
+			column[cpu] = i
+		}
+	}
+
+	rate := IRQRate{Name: name, CPUs: []int{}, PerCPU: []float64{}}
+	for i, cpu := range curCPUs {
+		j, ok := column[cpu]
+		if !ok || i >= len(cur) {
+			continue
+		}
+		var perSec float64
+		if cur[i] >= prev[j] {
+			perSec = float64(cur[i]-prev[j]) / seconds
+		}
+		rate.CPUs = append(rate.CPUs, cpu)
+		rate.PerCPU = append(rate.PerCPU, perSec)
+		rate.Total += perSec
+	}
+	return rate
+}
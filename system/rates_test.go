@@ -0,0 +1,156 @@
+package system
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInterruptRates(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name       string
+		prevCPUs   []int
+		prevCounts []uint64
+		curCPUs    []int
+		curCounts  []uint64
+		wantCPUs   []int
+		wantPerCPU []float64
+	}{
+		{
+			name:       "same cpus",
+			prevCPUs:   []int{0, 1},
+			prevCounts: []uint64{100, 200},
+			curCPUs:    []int{0, 1},
+			curCounts:  []uint64{300, 260},
+			wantCPUs:   []int{0, 1},
+			wantPerCPU: []float64{100, 30},
+		},
+		{
+			// cpu1 went offline: cpu2 now sits in the second column
+			name:       "cpu offlined",
+			prevCPUs:   []int{0, 1, 2},
+			prevCounts: []uint64{100, 5000, 200},
+			curCPUs:    []int{0, 2},
+			curCounts:  []uint64{300, 260},
+			wantCPUs:   []int{0, 2},
+			wantPerCPU: []float64{100, 30},
+		},
+		{
+			name:       "cpu onlined",
+			prevCPUs:   []int{0, 2},
+			prevCounts: []uint64{100, 200},
+			curCPUs:    []int{0, 1, 2},
+			curCounts:  []uint64{300, 40, 260},
+			wantCPUs:   []int{0, 2},
+			wantPerCPU: []float64{100, 30},
+		},
+		{
+			name:       "counter reset",
+			prevCPUs:   []int{0},
+			prevCounts: []uint64{500},
+			curCPUs:    []int{0},
+			curCounts:  []uint64{10},
+			wantCPUs:   []int{0},
+			wantPerCPU: []float64{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := &InterruptStats{
+				Timestamp:  start,
+				CPUs:       tt.prevCPUs,
+				Interrupts: []Interrupt{{IRQ: "LOC", PerCPU: tt.prevCounts}},
+			}
+			cur := &InterruptStats{
+				Timestamp:  start.Add(2 * time.Second),
+				CPUs:       tt.curCPUs,
+				Interrupts: []Interrupt{{IRQ: "LOC", PerCPU: tt.curCounts}},
+			}
+
+			rates := InterruptRates(prev, cur)
+			if len(rates) != 1 {
+				t.Fatalf("got %d rates, want 1", len(rates))
+			}
+			if !reflect.DeepEqual(rates[0].CPUs, tt.wantCPUs) {
+				t.Errorf("CPUs = %v, want %v", rates[0].CPUs, tt.wantCPUs)
+			}
+			if !reflect.DeepEqual(rates[0].PerCPU, tt.wantPerCPU) {
+				t.Errorf("PerCPU = %v, want %v", rates[0].PerCPU, tt.wantPerCPU)
+			}
+		})
+	}
+}
+
+func TestSoftIRQRates(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	prev := &SoftIRQStats{
+		Timestamp: start,
+		CPUs:      []int{0, 1, 2},
+		SoftIRQs: []SoftIRQ{
+			{Name: "TIMER", PerCPU: []uint64{1000, 2000, 3000}},
+			{Name: "NET_RX", PerCPU: []uint64{500, 600, 700}},
+		},
+	}
+	// cpu1 went offline and a softirq only present in the new sample is skipped
+	cur := &SoftIRQStats{
+		Timestamp: start.Add(4 * time.Second),
+		CPUs:      []int{0, 2},
+		SoftIRQs: []SoftIRQ{
+			{Name: "TIMER", PerCPU: []uint64{1400, 3100}},
+			{Name: "NET_RX", PerCPU: []uint64{100, 900}},
+			{Name: "POLL", PerCPU: []uint64{1, 1}},
+		},
+	}
+
+	want := []IRQRate{
+		{Name: "TIMER", CPUs: []int{0, 2}, PerCPU: []float64{100, 25}, Total: 125},
+		// NET_RX on cpu0 went backwards, which is reported as 0 rather than a huge rate
+		{Name: "NET_RX", CPUs: []int{0, 2}, PerCPU: []float64{0, 50}, Total: 50},
+	}
+	if got := SoftIRQRates(prev, cur); !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %+v\nwant %+v", got, want)
+	}
+
+	if got := SoftIRQRates(cur, cur); got != nil {
+		t.Errorf("zero interval: got %+v, want nil", got)
+	}
+}
+
+func TestKernelActivityRates(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	prev := &KernelActivity{Timestamp: start, ContextSwitches: 1000, Interrupts: 5000, Forks: 300}
+
+	tests := []struct {
+		name string
+		cur  KernelActivity
+		want KernelActivityRate
+	}{
+		{
+			name: "two seconds",
+			cur:  KernelActivity{Timestamp: start.Add(2 * time.Second), ContextSwitches: 3000, Interrupts: 5600, Forks: 310},
+			want: KernelActivityRate{ContextSwitchesPerSec: 1000, InterruptsPerSec: 300, ForksPerSec: 5},
+		},
+		{
+			// A counter that went backwards yields 0 rather than wrapping around
+			name: "counter reset",
+			cur:  KernelActivity{Timestamp: start.Add(2 * time.Second), ContextSwitches: 10, Interrupts: 5600, Forks: 310},
+			want: KernelActivityRate{ContextSwitchesPerSec: 0, InterruptsPerSec: 300, ForksPerSec: 5},
+		},
+		{
+			name: "zero interval",
+			cur:  KernelActivity{Timestamp: start, ContextSwitches: 3000, Interrupts: 5600, Forks: 310},
+			want: KernelActivityRate{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KernelActivityRates(prev, &tt.cur); *got != tt.want {
+				t.Errorf("got  %+v\nwant %+v", *got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ package system
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -30,6 +31,17 @@ type StealIOStats struct {
 	IOWaitPercent float64 `json:"iowait_percent"`
 }
 
+// KernelActivity represents scheduler and interrupt counters from /proc/stat
+type KernelActivity struct {
+	Timestamp       time.Time `json:"timestamp"`
+	ContextSwitches uint64    `json:"context_switches"`
+	Interrupts      uint64    `json:"interrupts"`
+	Forks           uint64    `json:"forks"`
+	ProcsRunning    uint64    `json:"procs_running"`
+	ProcsBlocked    uint64    `json:"procs_blocked"`
+	BootTime        time.Time `json:"boot_time"`
+}
+
 // GetLoadAvg returns the system load averages from /proc/loadavg
 func GetLoadAvg() (*LoadAvg, error) {
 	file, err := os.Open("/proc/loadavg")
@@ -111,7 +123,8 @@ func GetUptime() (*UptimeStats, error) {
 
 // GetStealIOWait returns the CPU steal and IO wait percentages
 // This is particularly useful for VPS environments where CPU steal indicates
-// the hypervisor is using CPU time that was allocated to this VM
+// the hypervisor is using CPU time that was allocated to this VM.
+// It blocks for 500ms; cpu.Sampler reports the same values without blocking.
 func GetStealIOWait() (*StealIOStats, error) {
 	readStats := func() (iowait, steal, total uint64, err error) {
 		file, err := os.Open("/proc/stat")
@@ -141,8 +154,11 @@ func GetStealIOWait() (*StealIOStats, error) {
 					steal = vals[7]
 				}
 
-				for _, v := range vals {
-					total += v
+				// guest and guest_nice are already included in user and nice
+				for i, v := range vals {
+					if i < 8 {
+						total += v
+					}
 				}
 
 				return iowait, steal, total, nil
@@ -177,3 +193,54 @@ func GetStealIOWait() (*StealIOStats, error) {
 		IOWaitPercent: float64(iowaitDelta) / float64(totalDelta) * 100,
 	}, nil
 }
+
+// GetKernelActivity returns context switch, interrupt and fork counters, the number of
+// running and blocked tasks, and the boot time from /proc/stat
+func GetKernelActivity() (*KernelActivity, error) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stats, err := parseKernelActivity(file)
+	if err != nil {
+		return nil, err
+	}
+	stats.Timestamp = time.Now()
+	return stats, nil
+}
+
+// parseKernelActivity parses the kernel counters of /proc/stat content
+func parseKernelActivity(r io.Reader) (*KernelActivity, error) {
+	stats := &KernelActivity{}
+	scanner := bufio.NewScanner(r)
+	// The intr line lists a counter for every IRQ and can exceed the default buffer
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		val, _ := strconv.ParseUint(fields[1], 10, 64)
+		switch fields[0] {
+		case "ctxt":
+			stats.ContextSwitches = val
+		case "intr":
+			// The first value is the total; the rest are per-IRQ counts
+			stats.Interrupts = val
+		case "processes":
+			stats.Forks = val
+		case "procs_running":
+			stats.ProcsRunning = val
+		case "procs_blocked":
+			stats.ProcsBlocked = val
+		case "btime":
+			stats.BootTime = time.Unix(int64(val), 0)
+		}
+	}
+
+	return stats, scanner.Err()
+}
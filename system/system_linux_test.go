@@ -0,0 +1,44 @@
+//go:build linux
+// +build linux
+
+package system
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseKernelActivity(t *testing.T) {
+	input := `cpu  400 10 200 4000 40 0 8 0 0 0
+cpu0 400 10 200 4000 40 0 8 0 0 0
+intr 628578 9 0 ` + strings.Repeat("0 ", 100000) + `
+ctxt 1452674
+btime 1792177342
+processes 23456
+procs_running 3
+procs_blocked 1
+softirq 412345 12 190000 3 4 5 0 6 7 8 9
+`
+	stats, err := parseKernelActivity(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := KernelActivity{
+		ContextSwitches: 1452674,
+		// Only the leading total of the intr line is kept
+		Interrupts:   628578,
+		Forks:        23456,
+		ProcsRunning: 3,
+		ProcsBlocked: 1,
+		BootTime:     time.Unix(1792177342, 0),
+	}
+	if !stats.BootTime.Equal(want.BootTime) {
+		t.Errorf("BootTime = %v, want %v", stats.BootTime, want.BootTime)
+	}
+	stats.BootTime = want.BootTime
+	if *stats != want {
+		t.Errorf("got  %+v\nwant %+v", *stats, want)
+	}
+}
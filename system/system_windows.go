@@ -2,6 +2,8 @@
 
 package system
 
+import "time"
+
 // LoadAvg represents system load averages
 type LoadAvg struct {
 	Load1  float64 `json:"load_1m"`
@@ -21,6 +23,17 @@ type StealIOStats struct {
 	IOWaitPercent float64 `json:"iowait_percent"`
 }
 
+// KernelActivity represents scheduler and interrupt counters
+type KernelActivity struct {
+	Timestamp       time.Time `json:"timestamp"`
+	ContextSwitches uint64    `json:"context_switches"`
+	Interrupts      uint64    `json:"interrupts"`
+	Forks           uint64    `json:"forks"`
+	ProcsRunning    uint64    `json:"procs_running"`
+	ProcsBlocked    uint64    `json:"procs_blocked"`
+	BootTime        time.Time `json:"boot_time"`
+}
+
 // GetLoadAvg returns mock load average statistics for Windows
 func GetLoadAvg() (*LoadAvg, error) {
 	return &LoadAvg{
@@ -46,3 +59,16 @@ func GetStealIOWait() (*StealIOStats, error) {
 		IOWaitPercent: 0.0,
 	}, nil
 }
+
+// GetKernelActivity returns mock kernel activity counters for Windows
+func GetKernelActivity() (*KernelActivity, error) {
+	return &KernelActivity{
+		Timestamp:       time.Now(),
+		ContextSwitches: 1000000,
+		Interrupts:      500000,
+		Forks:           10000,
+		ProcsRunning:    1,
+		ProcsBlocked:    0,
+		BootTime:        time.Now().Add(-24 * time.Hour),
+	}, nil
+}